@@ -0,0 +1,276 @@
+package backtest
+
+import (
+	"errors"
+	"math"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+// Context is the strategy's view of the run on the current bar. Everything it exposes
+// is limited to bars up to and including the current one.
+type Context struct {
+	engine  *Engine
+	bars    *indicators.OHLCV
+	index   int
+	pf      *portfolio
+	values  map[string][]float64
+	orders  []*Order
+	pending []*Order
+}
+
+// Index returns the index of the current bar.
+func (c *Context) Index() int {
+	return c.index
+}
+
+// Bar returns the current bar.
+func (c *Context) Bar() Bar {
+	return c.barAt(c.index)
+}
+
+func (c *Context) barAt(i int) Bar {
+	b := c.bars
+	bar := Bar{Index: i, High: b.High[i], Low: b.Low[i], Close: b.Close[i]}
+	switch {
+	case len(b.Open) > 0:
+		bar.Open = b.Open[i]
+	case i > 0:
+		bar.Open = b.Close[i-1]
+	default:
+		bar.Open = b.Close[i]
+	}
+	if len(b.Time) > 0 {
+		bar.Time = b.Time[i]
+	}
+	if len(b.Volume) > 0 {
+		bar.Volume = b.Volume[i]
+	}
+	return bar
+}
+
+// History returns the bars up to and including the current one. The slices share
+// memory with the input series but can't be extended into later bars.
+func (c *Context) History() *indicators.OHLCV {
+	end := c.index + 1
+	cut := func(s []float64) []float64 {
+		if len(s) == 0 {
+			return nil
+		}
+		return s[:end:end]
+	}
+	h := &indicators.OHLCV{
+		Open:   cut(c.bars.Open),
+		High:   cut(c.bars.High),
+		Low:    cut(c.bars.Low),
+		Close:  cut(c.bars.Close),
+		Volume: cut(c.bars.Volume),
+	}
+	if len(c.bars.Time) > 0 {
+		h.Time = c.bars.Time[:end:end]
+	}
+	return h
+}
+
+// Value returns the current value of a registered indicator, or math.NaN() if no
+// indicator has that name.
+func (c *Context) Value(name string) float64 {
+	return c.ValueAt(name, 0)
+}
+
+// ValueAt returns a registered indicator's value 'ago' bars back (0 is the current
+// bar), or math.NaN() if that bar or name doesn't exist.
+func (c *Context) ValueAt(name string, ago int) float64 {
+	vals := c.values[name]
+	i := len(vals) - 1 - ago
+	if ago < 0 || i < 0 {
+		return math.NaN()
+	}
+	return vals[i]
+}
+
+// Values returns a registered indicator's values up to the current bar.
+func (c *Context) Values(name string) []float64 {
+	vals := c.values[name]
+	return vals[:len(vals):len(vals)]
+}
+
+// Position returns the open position.
+func (c *Context) Position() Position {
+	return c.pf.pos
+}
+
+// Cash returns the cash balance.
+func (c *Context) Cash() float64 {
+	return c.pf.cash
+}
+
+// Equity returns cash plus the position marked to the current close.
+func (c *Context) Equity() float64 {
+	return c.pf.equity(c.bars.Close[c.index])
+}
+
+// PendingOrders returns the orders waiting to fill.
+func (c *Context) PendingOrders() []*Order {
+	return append([]*Order(nil), c.pending...)
+}
+
+// Submit places an order, which can fill from the next bar on. Only Side, Type,
+// Quantity, Price and Tag are taken from o.
+func (c *Context) Submit(o Order) (*Order, error) {
+	if o.Quantity <= 0 || math.IsNaN(o.Quantity) || math.IsInf(o.Quantity, 0) {
+		return nil, errors.New("order quantity must be positive")
+	}
+	if o.Type != Market && (o.Price <= 0 || math.IsNaN(o.Price) || math.IsInf(o.Price, 0)) {
+		return nil, errors.New("limit and stop orders need a positive price")
+	}
+	if o.Type < Market || o.Type > Stop || o.Side < Buy || o.Side > Sell {
+		return nil, errors.New("unknown order side or type")
+	}
+	order := c.newOrder(o.Side, o.Type, o.Quantity, o.Price, o.Tag)
+	c.pending = append(c.pending, order)
+	return order, nil
+}
+
+// Buy submits a market buy.
+func (c *Context) Buy(quantity float64) (*Order, error) {
+	return c.Submit(Order{Side: Buy, Type: Market, Quantity: quantity})
+}
+
+// Sell submits a market sell.
+func (c *Context) Sell(quantity float64) (*Order, error) {
+	return c.Submit(Order{Side: Sell, Type: Market, Quantity: quantity})
+}
+
+// BuyLimit submits a buy at price or lower.
+func (c *Context) BuyLimit(quantity, price float64) (*Order, error) {
+	return c.Submit(Order{Side: Buy, Type: Limit, Quantity: quantity, Price: price})
+}
+
+// SellLimit submits a sell at price or higher.
+func (c *Context) SellLimit(quantity, price float64) (*Order, error) {
+	return c.Submit(Order{Side: Sell, Type: Limit, Quantity: quantity, Price: price})
+}
+
+// BuyStop submits a buy triggered when the price reaches price.
+func (c *Context) BuyStop(quantity, price float64) (*Order, error) {
+	return c.Submit(Order{Side: Buy, Type: Stop, Quantity: quantity, Price: price})
+}
+
+// SellStop submits a sell triggered when the price falls to price, e.g. a stop loss.
+func (c *Context) SellStop(quantity, price float64) (*Order, error) {
+	return c.Submit(Order{Side: Sell, Type: Stop, Quantity: quantity, Price: price})
+}
+
+// ClosePosition cancels pending orders and submits a market order that flattens the
+// position. It returns nil if there is no position.
+func (c *Context) ClosePosition() (*Order, error) {
+	c.CancelAll()
+	q := c.pf.pos.Quantity
+	switch {
+	case q > 0:
+		return c.Submit(Order{Side: Sell, Type: Market, Quantity: q, Tag: "close"})
+	case q < 0:
+		return c.Submit(Order{Side: Buy, Type: Market, Quantity: -q, Tag: "close"})
+	}
+	return nil, nil
+}
+
+// Cancel cancels a pending order and reports whether it was pending.
+func (c *Context) Cancel(o *Order) bool {
+	for i, p := range c.pending {
+		if p == o {
+			o.Status, o.Reason = Cancelled, "cancelled by strategy"
+			c.pending = append(c.pending[:i], c.pending[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// CancelAll cancels every pending order.
+func (c *Context) CancelAll() {
+	for _, o := range c.pending {
+		o.Status, o.Reason = Cancelled, "cancelled by strategy"
+	}
+	c.pending = nil
+}
+
+func (c *Context) newOrder(side Side, typ OrderType, quantity, price float64, tag string) *Order {
+	o := &Order{
+		ID:           len(c.orders) + 1,
+		Side:         side,
+		Type:         typ,
+		Quantity:     quantity,
+		Price:        price,
+		Tag:          tag,
+		SubmittedBar: c.index,
+		FilledBar:    -1,
+	}
+	c.orders = append(c.orders, o)
+	return o
+}
+
+// fillPending fills, in submission order, the pending orders the bar's prices reach.
+func (c *Context) fillPending(bar Bar) {
+	var still []*Order
+	for _, o := range c.pending {
+		price, ok := c.fillPrice(o, bar)
+		if !ok {
+			still = append(still, o)
+			continue
+		}
+		if !c.engine.AllowShort && o.Side == Sell && c.pf.pos.Quantity-o.Quantity < -1e-9 {
+			o.Status, o.Reason = Rejected, "short selling is not allowed"
+			continue
+		}
+		// Buys that cover a short are always allowed; a buy that opens or adds to a
+		// long must be paid for with cash on hand.
+		if o.Side == Buy && c.pf.pos.Quantity+o.Quantity > 1e-9 &&
+			o.Quantity*price+c.engine.Commission.fee(o.Quantity, price) > c.pf.cash+1e-9 {
+			o.Status, o.Reason = Rejected, "insufficient cash"
+			continue
+		}
+		c.execute(o, price, bar)
+	}
+	c.pending = still
+}
+
+// fillPrice returns the price o would fill at on bar, if it fills. Orders that gap
+// through their price fill at the open.
+func (c *Context) fillPrice(o *Order, bar Bar) (float64, bool) {
+	slip := c.engine.Slippage
+	switch o.Type {
+	case Market:
+		return slip.apply(o.Side, bar.Open), true
+	case Limit:
+		if o.Side == Buy && bar.Low <= o.Price {
+			return math.Min(bar.Open, o.Price), true
+		}
+		if o.Side == Sell && bar.High >= o.Price {
+			return math.Max(bar.Open, o.Price), true
+		}
+	case Stop:
+		if o.Side == Buy && bar.High >= o.Price {
+			return slip.apply(Buy, math.Max(bar.Open, o.Price)), true
+		}
+		if o.Side == Sell && bar.Low <= o.Price {
+			return slip.apply(Sell, math.Min(bar.Open, o.Price)), true
+		}
+	}
+	return 0, false
+}
+
+func (c *Context) execute(o *Order, price float64, bar Bar) {
+	fee := c.engine.Commission.fee(o.Quantity, price)
+	o.Status = Filled
+	o.FilledBar, o.FilledTime = bar.Index, bar.Time
+	o.FillPrice, o.Commission = price, fee
+	c.pf.fill(o.Side, o.Quantity, price, fee, bar.Index, bar.Time)
+}
+
+func (c *Context) record(res *Result, i int) {
+	res.Equity[i] = c.pf.equity(c.bars.Close[i])
+	res.Cash[i] = c.pf.cash
+	res.Position[i] = c.pf.pos.Quantity
+}
@@ -0,0 +1,35 @@
+package backtest
+
+import "math"
+
+// Commission charged on every fill:
+//
+//	fee = max(Minimum, PerOrder + PerUnit*quantity + Percent*quantity*price)
+//
+// Percent is a fraction, e.g. 0.001 for 10 basis points.
+type Commission struct {
+	PerOrder float64
+	PerUnit  float64
+	Percent  float64
+	Minimum  float64
+}
+
+func (c Commission) fee(quantity, price float64) float64 {
+	fee := c.PerOrder + c.PerUnit*quantity + c.Percent*quantity*price
+	return math.Max(fee, c.Minimum)
+}
+
+// Slippage moves market and stop fills against the order by
+//
+//	price*Percent + PerUnit
+//
+// (up for buys, down for sells). Limit orders fill at their price or better and are
+// not slipped.
+type Slippage struct {
+	Percent float64
+	PerUnit float64
+}
+
+func (s Slippage) apply(side Side, price float64) float64 {
+	return price + side.sign()*(price*s.Percent+s.PerUnit)
+}
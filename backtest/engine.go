@@ -0,0 +1,192 @@
+// Package backtest replays a bar series through a strategy and simulates its orders.
+//
+// On every bar the engine
+//
+//  1. fills pending orders against the bar's prices,
+//  2. updates the registered indicators with the bar,
+//  3. marks the portfolio to the bar's close, and
+//  4. calls the strategy, which may submit or cancel orders.
+//
+// Indicators are fed one bar at a time, the strategy only sees bars up to the current
+// one, and orders can fill no earlier than the next bar, so results can't depend on
+// future data. SMA, EMA, RSI, ATR, MACD, Bollinger Bands, Stochastic, ADX, SuperTrend
+// and Parabolic SAR have NewStream methods with ready-made feeds (see feeds.go); any
+// other indicator can be wrapped in a Feed that updates it incrementally.
+package backtest
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+// Bar is a single bar of the series being replayed. When the series has no open
+// prices, Open is the previous bar's close (the first bar's own close).
+type Bar struct {
+	Index  int
+	Time   time.Time // zero if the series has no times
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume float64 // zero if the series has no volume
+}
+
+// Feed updates an indicator with a new bar and returns its current value
+// (math.NaN() during warm-up).
+type Feed func(bar Bar) float64
+
+// MultiFeed updates an indicator with several outputs, such as MACD or Bollinger
+// Bands, and returns them in a fixed order.
+type MultiFeed func(bar Bar) []float64
+
+// Strategy is called once per bar, after the bar closes. Returning an error stops the run.
+type Strategy func(ctx *Context) error
+
+// Engine holds the simulation settings and the indicators to feed.
+type Engine struct {
+	InitialCash float64
+	Commission  Commission
+	Slippage    Slippage
+	// AllowShort permits sells that take the position below zero; otherwise such
+	// orders are rejected when they would fill. Buys that would leave a long
+	// position and cost more than the cash on hand, commission included, are
+	// always rejected, so there is no leverage.
+	AllowShort bool
+	// CloseAtEnd closes any open position at the last bar's close, so it appears in
+	// the trade list.
+	CloseAtEnd bool
+
+	feeds []namedFeed
+}
+
+type namedFeed struct {
+	names []string
+	feed  MultiFeed
+}
+
+// NewEngine returns an engine with the given starting cash, no costs, long-only and
+// without leverage.
+func NewEngine(initialCash float64) *Engine {
+	return &Engine{InitialCash: initialCash}
+}
+
+// AddIndicator registers a feed whose values the strategy reads with Context.Value.
+// Feeds keep their own state, so register fresh ones before each Run.
+func (e *Engine) AddIndicator(name string, feed Feed) {
+	var multi MultiFeed
+	if feed != nil {
+		multi = func(bar Bar) []float64 { return []float64{feed(bar)} }
+	}
+	e.AddIndicators([]string{name}, multi)
+}
+
+// AddIndicators registers a feed with several outputs; output k is read with
+// Context.Value(names[k]). The feed is updated once per bar whichever outputs the
+// strategy reads.
+func (e *Engine) AddIndicators(names []string, feed MultiFeed) {
+	e.feeds = append(e.feeds, namedFeed{names: names, feed: feed})
+}
+
+// Result is the outcome of a run. Equity, Cash and Position hold one value per bar,
+// taken at the bar's close after that bar's fills.
+type Result struct {
+	InitialCash float64
+	Time        []time.Time // the series' times, if any
+	Equity      []float64
+	Cash        []float64
+	Position    []float64
+	Orders      []*Order // every order submitted, in submission order
+	Trades      []Trade
+}
+
+// FinalEquity returns the equity at the last bar.
+func (r *Result) FinalEquity() float64 {
+	if len(r.Equity) == 0 {
+		return r.InitialCash
+	}
+	return r.Equity[len(r.Equity)-1]
+}
+
+// Run replays bars through the strategy.
+func (e *Engine) Run(bars *indicators.OHLCV, strategy Strategy) (*Result, error) {
+	if bars == nil || strategy == nil {
+		return nil, errors.New("backtest needs bars and a strategy")
+	}
+	if err := bars.Validate(); err != nil {
+		return nil, err
+	}
+	if e.InitialCash <= 0 {
+		return nil, errors.New("initial cash must be positive")
+	}
+	seen := make(map[string]bool)
+	for _, f := range e.feeds {
+		if len(f.names) == 0 || f.feed == nil {
+			return nil, errors.New("indicators need a name and a feed")
+		}
+		for _, name := range f.names {
+			if name == "" {
+				return nil, errors.New("indicators need a name and a feed")
+			}
+			if seen[name] {
+				return nil, fmt.Errorf("indicator %q registered twice", name)
+			}
+			seen[name] = true
+		}
+	}
+
+	n := bars.Len()
+	ctx := &Context{
+		engine: e,
+		bars:   bars,
+		pf:     newPortfolio(e.InitialCash),
+		values: make(map[string][]float64),
+	}
+	res := &Result{
+		InitialCash: e.InitialCash,
+		Time:        bars.Time,
+		Equity:      make([]float64, n),
+		Cash:        make([]float64, n),
+		Position:    make([]float64, n),
+	}
+
+	for i := 0; i < n; i++ {
+		ctx.index = i
+		bar := ctx.Bar()
+		ctx.fillPending(bar)
+		for _, f := range e.feeds {
+			out := f.feed(bar)
+			if len(out) != len(f.names) {
+				return nil, fmt.Errorf("indicator %q returned %d values, want %d", f.names[0], len(out), len(f.names))
+			}
+			for k, name := range f.names {
+				ctx.values[name] = append(ctx.values[name], out[k])
+			}
+		}
+		ctx.record(res, i)
+		if err := strategy(ctx); err != nil {
+			return nil, fmt.Errorf("strategy failed on bar %d: %w", i, err)
+		}
+	}
+
+	for _, o := range ctx.pending {
+		o.Status, o.Reason = Cancelled, "end of data"
+	}
+	ctx.pending = nil
+	if last := ctx.Bar(); e.CloseAtEnd && ctx.pf.pos.Quantity != 0 {
+		side := Sell
+		if ctx.pf.pos.Quantity < 0 {
+			side = Buy
+		}
+		o := ctx.newOrder(side, Market, math.Abs(ctx.pf.pos.Quantity), 0, "close at end")
+		ctx.execute(o, e.Slippage.apply(side, last.Close), last)
+		ctx.record(res, n-1)
+	}
+
+	res.Orders = ctx.orders
+	res.Trades = ctx.pf.trades
+	return res, nil
+}
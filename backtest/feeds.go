@@ -0,0 +1,72 @@
+package backtest
+
+import "github.com/copyleftdev/indicator-libs/indicators"
+
+// Adapters from the indicators' streams to feeds. Register single-output feeds with
+// Engine.AddIndicator and multi-output ones with Engine.AddIndicators, e.g.
+//
+//	macd, _ := indicators.NewMACD(12, 26, 9).NewStream()
+//	eng.AddIndicators([]string{"macd", "signal", "hist"}, backtest.MACDFeed(macd))
+
+// CloseFeed adapts a stream updated with one price, such as an SMAStream, EMAStream or
+// RSIStream, to a Feed on closing prices.
+func CloseFeed(s interface{ Update(price float64) float64 }) Feed {
+	return func(bar Bar) float64 { return s.Update(bar.Close) }
+}
+
+// HLCFeed adapts a stream updated with high, low and close, such as an ATRStream.
+func HLCFeed(s interface {
+	Update(high, low, close float64) float64
+}) Feed {
+	return func(bar Bar) float64 { return s.Update(bar.High, bar.Low, bar.Close) }
+}
+
+// MACDFeed returns the MACD line, signal line and histogram on closing prices.
+func MACDFeed(s *indicators.MACDStream) MultiFeed {
+	return func(bar Bar) []float64 {
+		m, sig, hist := s.Update(bar.Close)
+		return []float64{m, sig, hist}
+	}
+}
+
+// BollingerBandsFeed returns the middle, upper and lower bands on closing prices.
+func BollingerBandsFeed(s *indicators.BollingerBandsStream) MultiFeed {
+	return func(bar Bar) []float64 {
+		mid, up, low := s.Update(bar.Close)
+		return []float64{mid, up, low}
+	}
+}
+
+// StochasticFeed returns %K and %D.
+func StochasticFeed(s *indicators.StochasticStream) MultiFeed {
+	return func(bar Bar) []float64 {
+		k, d := s.Update(bar.High, bar.Low, bar.Close)
+		return []float64{k, d}
+	}
+}
+
+// ADXFeed returns ADX, +DI and -DI.
+func ADXFeed(s *indicators.ADXStream) MultiFeed {
+	return func(bar Bar) []float64 {
+		adx, plus, minus := s.Update(bar.High, bar.Low, bar.Close)
+		return []float64{adx, plus, minus}
+	}
+}
+
+// SuperTrendFeed returns the SuperTrend line and direction (1 up, -1 down, 0 during
+// warm-up).
+func SuperTrendFeed(s *indicators.SuperTrendStream) MultiFeed {
+	return func(bar Bar) []float64 {
+		line, dir, _, _ := s.Update(bar.Open, bar.High, bar.Low, bar.Close)
+		return []float64{line, float64(dir)}
+	}
+}
+
+// ParabolicSARFeed returns the SAR and its direction (1 up, -1 down, 0 before the
+// trend is known).
+func ParabolicSARFeed(s *indicators.ParabolicSARStream) MultiFeed {
+	return func(bar Bar) []float64 {
+		sar, dir, _ := s.Update(bar.High, bar.Low)
+		return []float64{sar, float64(dir)}
+	}
+}
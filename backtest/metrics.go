@@ -0,0 +1,325 @@
+package backtest
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/stat"
+)
+
+// Performance and risk metrics for equity curves and trade lists. They work on any
+// equity series (not only Result.Equity) and return math.NaN() when a metric is
+// undefined, e.g. a Sharpe ratio of a flat curve. periodsPerYear annualizes per-bar
+// figures: 252 for daily stock bars, 365 for daily crypto bars, 52 for weekly bars.
+
+// Metrics summarizes a run.
+type Metrics struct {
+	TotalReturn         float64 // final / initial equity - 1
+	CAGR                float64
+	Sharpe              float64
+	Sortino             float64
+	Calmar              float64
+	MaxDrawdown         float64 // fraction of the peak, e.g. 0.25 for a 25% drawdown
+	MaxDrawdownDuration int     // longest stretch of bars below a previous peak
+	Exposure            float64 // fraction of bars with an open position
+	Trades              TradeStats
+}
+
+// Metrics computes the summary for the run with a zero risk-free rate.
+func (r *Result) Metrics(periodsPerYear float64) Metrics {
+	equity := append([]float64{r.InitialCash}, r.Equity...)
+	returns := Returns(equity)
+	return Metrics{
+		TotalReturn:         equity[len(equity)-1]/equity[0] - 1,
+		CAGR:                CAGR(equity, periodsPerYear),
+		Sharpe:              Sharpe(returns, 0, periodsPerYear),
+		Sortino:             Sortino(returns, 0, periodsPerYear),
+		Calmar:              Calmar(equity, periodsPerYear),
+		MaxDrawdown:         MaxDrawdown(equity).Depth,
+		MaxDrawdownDuration: MaxDrawdownDuration(equity),
+		Exposure:            Exposure(r.Position),
+		Trades:              NewTradeStats(r.Trades),
+	}
+}
+
+// Returns converts an equity curve to simple per-bar returns; the result has one
+// value fewer than equity.
+func Returns(equity []float64) []float64 {
+	if len(equity) < 2 {
+		return nil
+	}
+	out := make([]float64, len(equity)-1)
+	for i := 1; i < len(equity); i++ {
+		out[i-1] = equity[i]/equity[i-1] - 1
+	}
+	return out
+}
+
+// Sharpe is the annualized Sharpe ratio: the mean excess return over the per-bar
+// risk-free rate divided by the standard deviation of returns, times sqrt(periodsPerYear).
+func Sharpe(returns []float64, riskFree, periodsPerYear float64) float64 {
+	if len(returns) < 2 {
+		return math.NaN()
+	}
+	sd := stat.StdDev(returns, nil)
+	if sd == 0 {
+		return math.NaN()
+	}
+	return (stat.Mean(returns, nil) - riskFree) / sd * math.Sqrt(periodsPerYear)
+}
+
+// Sortino is like Sharpe but only penalizes returns below the per-bar target:
+// the denominator is the downside deviation sqrt(mean(min(0, r - target)^2)).
+func Sortino(returns []float64, target, periodsPerYear float64) float64 {
+	if len(returns) < 2 {
+		return math.NaN()
+	}
+	var sumSq float64
+	for _, r := range returns {
+		if d := r - target; d < 0 {
+			sumSq += d * d
+		}
+	}
+	dd := math.Sqrt(sumSq / float64(len(returns)))
+	if dd == 0 {
+		return math.NaN()
+	}
+	return (stat.Mean(returns, nil) - target) / dd * math.Sqrt(periodsPerYear)
+}
+
+// CAGR is the compound annual growth rate from the first to the last equity value,
+// treating the curve as len(equity)-1 periods.
+func CAGR(equity []float64, periodsPerYear float64) float64 {
+	n := len(equity)
+	if n < 2 || equity[0] <= 0 || periodsPerYear <= 0 {
+		return math.NaN()
+	}
+	if equity[n-1] <= 0 {
+		return -1
+	}
+	years := float64(n-1) / periodsPerYear
+	return math.Pow(equity[n-1]/equity[0], 1/years) - 1
+}
+
+// Calmar is CAGR divided by the maximum drawdown.
+func Calmar(equity []float64, periodsPerYear float64) float64 {
+	dd := MaxDrawdown(equity).Depth
+	if dd == 0 || math.IsNaN(dd) {
+		return math.NaN()
+	}
+	return CAGR(equity, periodsPerYear) / dd
+}
+
+// Drawdown describes the deepest decline of an equity curve.
+type Drawdown struct {
+	Depth    float64 // (peak - trough) / peak
+	Peak     int     // index of the peak before the decline
+	Trough   int     // index of the lowest point
+	Recovery int     // first index back at or above the peak; -1 if not recovered
+}
+
+// MaxDrawdown finds the deepest peak-to-trough decline.
+func MaxDrawdown(equity []float64) Drawdown {
+	dd := Drawdown{Recovery: -1}
+	if len(equity) == 0 {
+		dd.Depth = math.NaN()
+		return dd
+	}
+	peak := 0
+	for i, v := range equity {
+		if v > equity[peak] {
+			peak = i
+		}
+		if depth := (equity[peak] - v) / equity[peak]; depth > dd.Depth {
+			dd = Drawdown{Depth: depth, Peak: peak, Trough: i, Recovery: -1}
+		}
+	}
+	if dd.Depth > 0 {
+		for i := dd.Trough + 1; i < len(equity); i++ {
+			if equity[i] >= equity[dd.Peak] {
+				dd.Recovery = i
+				break
+			}
+		}
+	}
+	return dd
+}
+
+// DrawdownSeries returns the drawdown from the running peak at each bar, as a
+// non-negative fraction.
+func DrawdownSeries(equity []float64) []float64 {
+	out := make([]float64, len(equity))
+	peak := math.Inf(-1)
+	for i, v := range equity {
+		peak = math.Max(peak, v)
+		out[i] = (peak - v) / peak
+	}
+	return out
+}
+
+// MaxDrawdownDuration is the longest run of bars spent below a previous peak,
+// whether or not the curve recovered.
+func MaxDrawdownDuration(equity []float64) int {
+	longest, run := 0, 0
+	peak := math.Inf(-1)
+	for _, v := range equity {
+		if v >= peak {
+			peak, run = v, 0
+			continue
+		}
+		run++
+		if run > longest {
+			longest = run
+		}
+	}
+	return longest
+}
+
+// Exposure is the fraction of bars with a non-zero position.
+func Exposure(position []float64) float64 {
+	if len(position) == 0 {
+		return math.NaN()
+	}
+	held := 0
+	for _, q := range position {
+		if q != 0 {
+			held++
+		}
+	}
+	return float64(held) / float64(len(position))
+}
+
+// TradeStats summarizes a trade list. Trades with zero PnL count as neither wins
+// nor losses.
+type TradeStats struct {
+	Count        int
+	Wins         int
+	Losses       int
+	WinRate      float64 // Wins / Count
+	GrossProfit  float64 // sum of winning PnL
+	GrossLoss    float64 // sum of losing PnL, as a positive number
+	ProfitFactor float64 // GrossProfit / GrossLoss; +Inf with no losses
+	AvgWin       float64
+	AvgLoss      float64 // positive
+	Expectancy   float64 // average PnL per trade
+	AvgReturn    float64 // average Trade.Return
+}
+
+// NewTradeStats computes TradeStats for trades.
+func NewTradeStats(trades []Trade) TradeStats {
+	s := TradeStats{Count: len(trades)}
+	if len(trades) == 0 {
+		nan := math.NaN()
+		s.WinRate, s.ProfitFactor, s.AvgWin, s.AvgLoss, s.Expectancy, s.AvgReturn = nan, nan, nan, nan, nan, nan
+		return s
+	}
+	var net, ret float64
+	for _, t := range trades {
+		net += t.PnL
+		ret += t.Return
+		switch {
+		case t.PnL > 0:
+			s.Wins++
+			s.GrossProfit += t.PnL
+		case t.PnL < 0:
+			s.Losses++
+			s.GrossLoss -= t.PnL
+		}
+	}
+	n := float64(len(trades))
+	s.WinRate = float64(s.Wins) / n
+	s.Expectancy = net / n
+	s.AvgReturn = ret / n
+	s.AvgWin, s.AvgLoss = math.NaN(), math.NaN()
+	if s.Wins > 0 {
+		s.AvgWin = s.GrossProfit / float64(s.Wins)
+	}
+	if s.Losses > 0 {
+		s.AvgLoss = s.GrossLoss / float64(s.Losses)
+	}
+	switch {
+	case s.GrossLoss > 0:
+		s.ProfitFactor = s.GrossProfit / s.GrossLoss
+	case s.GrossProfit > 0:
+		s.ProfitFactor = math.Inf(1)
+	default:
+		s.ProfitFactor = math.NaN()
+	}
+	return s
+}
+
+// RollingTradeStats returns, for each trade, the stats of the last n trades ending
+// with it. Entries before the first full window have Count < n. It returns nil if n
+// is less than 1.
+func RollingTradeStats(trades []Trade, n int) []TradeStats {
+	if n < 1 {
+		return nil
+	}
+	out := make([]TradeStats, len(trades))
+	for i := range trades {
+		out[i] = NewTradeStats(trades[max(0, i-n+1) : i+1])
+	}
+	return out
+}
+
+// Rolling applies metric to each trailing window of window+1 equity values (window
+// returns). The first window values are math.NaN().
+func Rolling(equity []float64, window int, metric func(equity []float64) float64) []float64 {
+	out := make([]float64, len(equity))
+	for i := range out {
+		if window < 1 || i < window {
+			out[i] = math.NaN()
+			continue
+		}
+		out[i] = metric(equity[i-window : i+1])
+	}
+	return out
+}
+
+// RollingSharpe is Sharpe over each trailing window of returns.
+func RollingSharpe(equity []float64, window int, riskFree, periodsPerYear float64) []float64 {
+	return Rolling(equity, window, func(e []float64) float64 {
+		return Sharpe(Returns(e), riskFree, periodsPerYear)
+	})
+}
+
+// RollingSortino is Sortino over each trailing window of returns.
+func RollingSortino(equity []float64, window int, target, periodsPerYear float64) []float64 {
+	return Rolling(equity, window, func(e []float64) float64 {
+		return Sortino(Returns(e), target, periodsPerYear)
+	})
+}
+
+// RollingCAGR is CAGR over each trailing window.
+func RollingCAGR(equity []float64, window int, periodsPerYear float64) []float64 {
+	return Rolling(equity, window, func(e []float64) float64 {
+		return CAGR(e, periodsPerYear)
+	})
+}
+
+// RollingCalmar is Calmar over each trailing window.
+func RollingCalmar(equity []float64, window int, periodsPerYear float64) []float64 {
+	return Rolling(equity, window, func(e []float64) float64 {
+		return Calmar(e, periodsPerYear)
+	})
+}
+
+// RollingMaxDrawdown is the maximum drawdown within each trailing window.
+func RollingMaxDrawdown(equity []float64, window int) []float64 {
+	return Rolling(equity, window, func(e []float64) float64 {
+		return MaxDrawdown(e).Depth
+	})
+}
+
+// RollingExposure is the fraction of bars with a position over each trailing window
+// of 'window' bars.
+func RollingExposure(position []float64, window int) []float64 {
+	out := make([]float64, len(position))
+	for i := range out {
+		if window < 1 || i < window-1 {
+			out[i] = math.NaN()
+			continue
+		}
+		out[i] = Exposure(position[i-window+1 : i+1])
+	}
+	return out
+}
@@ -0,0 +1,88 @@
+package backtest
+
+import "time"
+
+// Side is the direction of an order.
+type Side int
+
+const (
+	Buy Side = iota
+	Sell
+)
+
+func (s Side) String() string {
+	if s == Sell {
+		return "sell"
+	}
+	return "buy"
+}
+
+func (s Side) sign() float64 {
+	if s == Sell {
+		return -1
+	}
+	return 1
+}
+
+// OrderType selects how an order is filled.
+type OrderType int
+
+const (
+	// Market fills at the open of the next bar.
+	Market OrderType = iota
+	// Limit buys at Price or lower (sells at Price or higher) once a bar trades there.
+	Limit
+	// Stop buys once a bar trades at or above Price (sells at or below), then fills like a market order.
+	Stop
+)
+
+func (t OrderType) String() string {
+	switch t {
+	case Limit:
+		return "limit"
+	case Stop:
+		return "stop"
+	}
+	return "market"
+}
+
+// OrderStatus is the state of an order.
+type OrderStatus int
+
+const (
+	Pending OrderStatus = iota
+	Filled
+	Cancelled
+	Rejected
+)
+
+func (s OrderStatus) String() string {
+	switch s {
+	case Filled:
+		return "filled"
+	case Cancelled:
+		return "cancelled"
+	case Rejected:
+		return "rejected"
+	}
+	return "pending"
+}
+
+// Order is an order submitted by a strategy. Orders stay pending, from the bar after
+// they were submitted, until they fill or are cancelled.
+type Order struct {
+	ID       int
+	Side     Side
+	Type     OrderType
+	Quantity float64 // always positive
+	Price    float64 // limit or stop price; unused for market orders
+	Tag      string  // free-form label, e.g. "entry" or "stop loss"
+
+	Status       OrderStatus
+	SubmittedBar int
+	FilledBar    int // -1 until filled
+	FilledTime   time.Time
+	FillPrice    float64 // including slippage
+	Commission   float64
+	Reason       string // why the order was rejected or cancelled
+}
@@ -0,0 +1,103 @@
+package backtest
+
+import (
+	"math"
+	"time"
+)
+
+// Position is the open position: Quantity is positive when long and negative when short.
+type Position struct {
+	Quantity  float64
+	AvgPrice  float64 // average entry price
+	EntryBar  int     // bar on which the position was opened; -1 when flat
+	EntryTime time.Time
+}
+
+// Trade is a closed (or partly closed) position. Reducing a position records a trade
+// for the quantity closed, at the position's average entry price.
+type Trade struct {
+	Direction  int // 1 for long, -1 for short
+	Quantity   float64
+	EntryBar   int
+	ExitBar    int
+	EntryTime  time.Time
+	ExitTime   time.Time
+	EntryPrice float64
+	ExitPrice  float64
+	Commission float64 // the entry commission for this quantity plus the exit commission
+	PnL        float64 // net of commission
+	Return     float64 // PnL relative to the entry value, EntryPrice * Quantity
+}
+
+// portfolio tracks cash and a single netted position.
+type portfolio struct {
+	cash      float64
+	pos       Position
+	entryFees float64 // entry commission not yet allocated to a trade
+	trades    []Trade
+}
+
+func newPortfolio(cash float64) *portfolio {
+	return &portfolio{cash: cash, pos: Position{EntryBar: -1}}
+}
+
+func (p *portfolio) equity(price float64) float64 {
+	return p.cash + p.pos.Quantity*price
+}
+
+// fill applies an executed order of 'quantity' units at 'price' with commission 'fee'.
+func (p *portfolio) fill(side Side, quantity, price, fee float64, bar int, t time.Time) {
+	p.cash -= side.sign()*quantity*price + fee
+
+	held := math.Abs(p.pos.Quantity)
+	dir := 1.0
+	if p.pos.Quantity < 0 {
+		dir = -1
+	}
+	if held == 0 || dir == side.sign() {
+		p.open(side, quantity, price, fee, bar, t)
+		return
+	}
+
+	closed := math.Min(quantity, held)
+	entryFee := p.entryFees * closed / held
+	exitFee := fee * closed / quantity
+	p.entryFees -= entryFee
+	gross := dir * (price - p.pos.AvgPrice) * closed
+	trade := Trade{
+		Direction:  int(dir),
+		Quantity:   closed,
+		EntryBar:   p.pos.EntryBar,
+		ExitBar:    bar,
+		EntryTime:  p.pos.EntryTime,
+		ExitTime:   t,
+		EntryPrice: p.pos.AvgPrice,
+		ExitPrice:  price,
+		Commission: entryFee + exitFee,
+		PnL:        gross - entryFee - exitFee,
+	}
+	if value := p.pos.AvgPrice * closed; value != 0 {
+		trade.Return = trade.PnL / value
+	}
+	p.trades = append(p.trades, trade)
+
+	p.pos.Quantity -= dir * closed
+	if closed == held {
+		p.pos = Position{EntryBar: -1}
+		p.entryFees = 0
+	}
+	// Anything beyond the old position opens a new one the other way.
+	if rest := quantity - closed; rest > 0 {
+		p.open(side, rest, price, fee*rest/quantity, bar, t)
+	}
+}
+
+func (p *portfolio) open(side Side, quantity, price, fee float64, bar int, t time.Time) {
+	held := math.Abs(p.pos.Quantity)
+	if held == 0 {
+		p.pos.EntryBar, p.pos.EntryTime = bar, t
+	}
+	p.pos.AvgPrice = (p.pos.AvgPrice*held + price*quantity) / (held + quantity)
+	p.pos.Quantity += side.sign() * quantity
+	p.entryFees += fee
+}
@@ -8,44 +8,81 @@ import (
 // ADX calculates the Average Directional Index, returning ADX, +DI, and -DI slices.
 // Uses a default Wilder's smoothing approach. The window is often 14.
 type ADX struct {
+	// Window is the smoothing length for TR, +DM and -DM (the DI length).
 	Window int
+	// ADXWindow is the smoothing length applied to DX to produce ADX.
+	// Zero means the same as Window, with ADX seeded as in earlier releases
+	// (see CalculateDMI).
+	ADXWindow int
 }
 
 func NewADX(window int) *ADX {
 	return &ADX{Window: window}
 }
 
+// NewADXWithSmoothing creates an ADX with separate DI and ADX smoothing lengths,
+// as offered by most charting platforms (e.g. DI length 14, ADX smoothing 14).
+func NewADXWithSmoothing(diWindow, adxWindow int) *ADX {
+	return &ADX{Window: diWindow, ADXWindow: adxWindow}
+}
+
+// DMIResult holds every series of Wilder's Directional Movement System.
+// All slices have the same length as the input; bars that can't be computed yet are 0.
+type DMIResult struct {
+	ADX     []float64 // Wilder-smoothed DX
+	ADXR    []float64 // (ADX[i] + ADX[i-ADXWindow]) / 2
+	DX      []float64 // raw directional index: |+DI - -DI| / (+DI + -DI) * 100
+	PlusDI  []float64
+	MinusDI []float64
+	PlusDM  []float64 // Wilder-smoothed +DM (running sum over Window)
+	MinusDM []float64 // Wilder-smoothed -DM (running sum over Window)
+}
+
 // Calculate returns three slices: ADX, +DI, and -DI, each the same length as input.
 func (a *ADX) Calculate(highs, lows, closes []float64) ([]float64, []float64, []float64, error) {
+	dmi, err := a.CalculateDMI(highs, lows, closes)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return dmi.ADX, dmi.PlusDI, dmi.MinusDI, nil
+}
+
+// CalculateDMI returns the full set of directional movement series, including
+// raw DX, smoothed +DM/-DM and ADXR.
+//
+// DI values start at index Window-1. With ADXWindow left at zero, ADX keeps its
+// original seeding and starts at index Window-1. With ADXWindow set, ADX is seeded
+// with the average of the first ADXWindow DX values once DI is available, so it
+// starts at index Window+ADXWindow-2. ADXR starts ADXWindow bars after ADX.
+func (a *ADX) CalculateDMI(highs, lows, closes []float64) (*DMIResult, error) {
 	if len(highs) != len(lows) || len(lows) != len(closes) {
-		return nil, nil, nil, errors.New("highs, lows, and closes must have the same length")
+		return nil, errors.New("highs, lows, and closes must have the same length")
+	}
+	if a.Window < 1 || a.ADXWindow < 0 {
+		return nil, errors.New("invalid ADX window")
 	}
 	if len(highs) < a.Window {
-		return nil, nil, nil, errors.New("not enough data for ADX")
+		return nil, errors.New("not enough data for ADX")
+	}
+	adxWindow := a.ADXWindow
+	if adxWindow == 0 {
+		adxWindow = a.Window
 	}
 
 	length := len(highs)
 
 	// True Range, +DM, -DM
-	tr := make([]float64, length)
+	tr := trueRange(highs, lows, closes)
 	pDM := make([]float64, length)
 	mDM := make([]float64, length)
 
 	// Initialize first day
-	tr[0] = highs[0] - lows[0]
 	pDM[0] = 0
 	mDM[0] = 0
 
 	for i := 1; i < length; i++ {
 		currentHigh := highs[i]
 		currentLow := lows[i]
-		prevClose := closes[i-1]
-
-		// True Range
-		range1 := currentHigh - currentLow
-		range2 := math.Abs(currentHigh - prevClose)
-		range3 := math.Abs(currentLow - prevClose)
-		tr[i] = max(range1, max(range2, range3)) // uses max from utils.go
 
 		// +DM and -DM
 		upMove := currentHigh - highs[i-1]
@@ -117,16 +154,132 @@ func (a *ADX) Calculate(highs, lows, closes []float64) ([]float64, []float64, []
 	}
 
 	adx := make([]float64, length)
-	// first ADX at index (window - 1): average of DX over that window
-	var sumDX float64
-	for i := a.Window - 1 - (a.Window - 1); i <= a.Window-1; i++ {
-		sumDX += dx[i]
+	firstADX := a.Window - 1
+	if a.ADXWindow == 0 {
+		// Original seeding, kept so existing NewADX results don't change: the first ADX
+		// at index Window-1 averages DX over the first Window bars, including the
+		// zero-padded bars before DI is available.
+		var sumDX float64
+		for i := 0; i <= firstADX; i++ {
+			sumDX += dx[i]
+		}
+		adx[firstADX] = sumDX / float64(adxWindow)
+	} else {
+		// First ADX: the average of the first adxWindow DX values once DI is available.
+		firstADX = a.Window - 1 + adxWindow - 1
+		if firstADX < length {
+			var sumDX float64
+			for i := a.Window - 1; i <= firstADX; i++ {
+				sumDX += dx[i]
+			}
+			adx[firstADX] = sumDX / float64(adxWindow)
+		}
+	}
+	for i := firstADX + 1; i < length; i++ {
+		adx[i] = ((adx[i-1] * float64(adxWindow-1)) + dx[i]) / float64(adxWindow)
 	}
-	adx[a.Window-1] = sumDX / float64(a.Window)
 
-	for i := a.Window; i < length; i++ {
-		adx[i] = ((adx[i-1] * float64(a.Window-1)) + dx[i]) / float64(a.Window)
+	// ADXR: average of the current ADX and the ADX adxWindow bars ago
+	adxr := make([]float64, length)
+	for i := firstADX + adxWindow; i < length; i++ {
+		adxr[i] = (adx[i] + adx[i-adxWindow]) / 2
+	}
+
+	return &DMIResult{
+		ADX:     adx,
+		ADXR:    adxr,
+		DX:      dx,
+		PlusDI:  plusDI,
+		MinusDI: minusDI,
+		PlusDM:  smPDM,
+		MinusDM: smMDM,
+	}, nil
+}
+
+// ADXStream computes the ADX, +DI and -DI one bar at a time and matches Calculate
+// after warm-up. +DI and -DI are math.NaN() for the first Window-1 bars and ADX until
+// its first value (see CalculateDMI), where Calculate reports 0.
+type ADXStream struct {
+	cfg       ADX
+	adxWindow int
+	count     int
+	prevHigh  float64
+	prevLow   float64
+	prevClose float64
+	smTR      float64 // running sums until Window bars, then Wilder-smoothed
+	smPDM     float64
+	smMDM     float64
+	sumDX     float64 // DX summed while seeding ADX
+	adx       float64
+}
+
+// NewStream returns an ADXStream with this configuration.
+func (a *ADX) NewStream() (*ADXStream, error) {
+	if a.Window < 1 || a.ADXWindow < 0 {
+		return nil, errors.New("invalid ADX window")
+	}
+	adxWindow := a.ADXWindow
+	if adxWindow == 0 {
+		adxWindow = a.Window
+	}
+	return &ADXStream{cfg: *a, adxWindow: adxWindow}, nil
+}
+
+// Update adds a bar and returns the ADX, +DI and -DI.
+func (st *ADXStream) Update(high, low, close float64) (float64, float64, float64) {
+	tr := high - low
+	var pDM, mDM float64
+	if st.count > 0 {
+		tr = max(tr, max(math.Abs(high-st.prevClose), math.Abs(low-st.prevClose)))
+		upMove := high - st.prevHigh
+		downMove := st.prevLow - low
+		if upMove > downMove && upMove > 0 {
+			pDM = upMove
+		}
+		if downMove > upMove && downMove > 0 {
+			mDM = downMove
+		}
 	}
+	st.prevHigh, st.prevLow, st.prevClose = high, low, close
+	i := st.count
+	st.count++
 
-	return adx, plusDI, minusDI, nil
+	w := float64(st.cfg.Window)
+	if i < st.cfg.Window {
+		st.smTR += tr
+		st.smPDM += pDM
+		st.smMDM += mDM
+	} else {
+		st.smTR = st.smTR - st.smTR/w + tr
+		st.smPDM = st.smPDM - st.smPDM/w + pDM
+		st.smMDM = st.smMDM - st.smMDM/w + mDM
+	}
+	if i < st.cfg.Window-1 {
+		return math.NaN(), math.NaN(), math.NaN()
+	}
+
+	var plusDI, minusDI, dx float64
+	if st.smTR != 0 {
+		plusDI = st.smPDM / st.smTR * 100
+		minusDI = st.smMDM / st.smTR * 100
+	}
+	if sum := plusDI + minusDI; sum != 0 {
+		dx = math.Abs(plusDI-minusDI) / sum * 100
+	}
+
+	firstADX := st.cfg.Window - 1
+	if st.cfg.ADXWindow != 0 {
+		firstADX += st.adxWindow - 1
+	}
+	aw := float64(st.adxWindow)
+	switch {
+	case i < firstADX:
+		st.sumDX += dx
+		return math.NaN(), plusDI, minusDI
+	case i == firstADX:
+		st.adx = (st.sumDX + dx) / aw
+	default:
+		st.adx = (st.adx*(aw-1) + dx) / aw
+	}
+	return st.adx, plusDI, minusDI
 }
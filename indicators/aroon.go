@@ -0,0 +1,70 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+/*
+Aroon Indicator:
+-----------------------------------------
+Developed by Tushar Chande (1995). Measures how recently the highest high and
+lowest low occurred within the last 'period' bars (a window of period+1 bars):
+
+	AroonUp    = 100 * (period - bars since highest high) / period
+	AroonDown  = 100 * (period - bars since lowest low)  / period
+	Oscillator = AroonUp - AroonDown
+
+When several bars share the extreme, the most recent one is used.
+-----------------------------------------
+*/
+type Aroon struct {
+	Period int
+}
+
+// NewAroon returns an Aroon instance with the given lookback (often 25).
+func NewAroon(period int) *Aroon {
+	return &Aroon{Period: period}
+}
+
+// Calculate returns three slices (aroonUp, aroonDown, oscillator), each the same length as the inputs.
+// The first 'Period' values are math.NaN().
+func (a *Aroon) Calculate(highs, lows []float64) ([]float64, []float64, []float64, error) {
+	n := len(highs)
+	if n != len(lows) {
+		return nil, nil, nil, errors.New("highs and lows must have the same length")
+	}
+	if a.Period < 1 {
+		return nil, nil, nil, errors.New("period must be >= 1 for Aroon")
+	}
+	if n <= a.Period {
+		return nil, nil, nil, errors.New("not enough data for Aroon")
+	}
+
+	up := make([]float64, n)
+	down := make([]float64, n)
+	osc := make([]float64, n)
+	for i := 0; i < a.Period; i++ {
+		up[i] = math.NaN()
+		down[i] = math.NaN()
+		osc[i] = math.NaN()
+	}
+
+	p := float64(a.Period)
+	for i := a.Period; i < n; i++ {
+		highIdx, lowIdx := i-a.Period, i-a.Period
+		for j := i - a.Period + 1; j <= i; j++ {
+			if highs[j] >= highs[highIdx] {
+				highIdx = j
+			}
+			if lows[j] <= lows[lowIdx] {
+				lowIdx = j
+			}
+		}
+		up[i] = 100 * (p - float64(i-highIdx)) / p
+		down[i] = 100 * (p - float64(i-lowIdx)) / p
+		osc[i] = up[i] - down[i]
+	}
+
+	return up, down, osc, nil
+}
@@ -8,6 +8,9 @@ import (
 // ATR calculates the Average True Range for a given window using Wilder's smoothing.
 type ATR struct {
 	Window int
+	// Smoothing selects how True Range is averaged. The default is Wilder's smoothing;
+	// MASimple matches the "SMA" ATR option offered by some charting platforms.
+	Smoothing MAType
 }
 
 func NewATR(window int) *ATR {
@@ -15,7 +18,8 @@ func NewATR(window int) *ATR {
 }
 
 // Calculate expects three slices: highs, lows, closes. Returns a slice of ATR values.
-// For the first (window-1) data points, ATR is set to 0. Then Wilder smoothing is applied.
+// For the first (window-1) data points, ATR is set to 0. Then Wilder smoothing
+// (or the configured Smoothing) is applied.
 func (a *ATR) Calculate(highs, lows, closes []float64) ([]float64, error) {
 	if len(highs) != len(lows) || len(lows) != len(closes) {
 		return nil, errors.New("highs, lows, and closes must have the same length")
@@ -24,41 +28,61 @@ func (a *ATR) Calculate(highs, lows, closes []float64) ([]float64, error) {
 		return nil, errors.New("not enough data for ATR")
 	}
 
-	length := len(highs)
-	tr := make([]float64, length)
+	tr := trueRange(highs, lows, closes)
 
-	// First TR can simply be high - low, or incorporate previous day close if needed
-	tr[0] = highs[0] - lows[0]
+	// Initial average TR at index window-1, then Wilder's smoothing.
+	// The first (window-1) values are reported as 0.
+	atr, err := smooth(tr, 0, a.Window, a.Smoothing.resolve(MAWilder))
+	if err != nil {
+		return nil, err
+	}
+
+	return nanToZero(atr), nil
+}
 
-	// True Range from day 1 onward
-	// TR = max(high[i] - low[i], |high[i] - close[i-1]|, |low[i] - close[i-1]|)
-	for i := 1; i < length; i++ {
+// trueRange returns the True Range of each bar:
+//
+//	TR = max(high[i] - low[i], |high[i] - close[i-1]|, |low[i] - close[i-1]|)
+//
+// The first bar has no previous close, so its TR is simply high - low.
+func trueRange(highs, lows, closes []float64) []float64 {
+	tr := make([]float64, len(highs))
+	if len(highs) == 0 {
+		return tr
+	}
+	tr[0] = highs[0] - lows[0]
+	for i := 1; i < len(highs); i++ {
 		range1 := highs[i] - lows[i]
 		range2 := math.Abs(highs[i] - closes[i-1])
 		range3 := math.Abs(lows[i] - closes[i-1])
 		tr[i] = max(range1, max(range2, range3)) // uses max from utils.go
 	}
+	return tr
+}
 
-	// Allocate ATR output
-	atr := make([]float64, length)
-
-	// First (window-1) are set to 0 (or could be math.NaN())
-	for i := 0; i < a.Window-1; i++ {
-		atr[i] = 0
-	}
+// ATRStream computes the ATR one bar at a time. After warm-up it matches Calculate;
+// the first Window-1 values are math.NaN() where Calculate reports 0.
+type ATRStream struct {
+	ma        *maStream
+	prevClose float64
+	started   bool
+}
 
-	// Initial average TR for index window-1
-	var sumTR float64
-	for i := 0; i < a.Window; i++ {
-		sumTR += tr[i]
+// NewStream returns an ATRStream with this ATR's configuration.
+func (a *ATR) NewStream() (*ATRStream, error) {
+	ma, err := newMAStream(a.Smoothing.resolve(MAWilder), a.Window)
+	if err != nil {
+		return nil, err
 	}
-	atr[a.Window-1] = sumTR / float64(a.Window)
+	return &ATRStream{ma: ma}, nil
+}
 
-	// Wilder's smoothing
-	for i := a.Window; i < length; i++ {
-		prev := atr[i-1]
-		atr[i] = ((prev * float64(a.Window-1)) + tr[i]) / float64(a.Window)
+// Update adds a bar and returns the current ATR.
+func (st *ATRStream) Update(high, low, close float64) float64 {
+	tr := high - low
+	if st.started {
+		tr = max(tr, max(math.Abs(high-st.prevClose), math.Abs(low-st.prevClose)))
 	}
-
-	return atr, nil
+	st.prevClose, st.started = close, true
+	return st.ma.update(tr)
 }
@@ -0,0 +1,75 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/stat"
+)
+
+/*
+Rolling Beta:
+-----------------------------------------
+Sensitivity of an asset to a benchmark, measured on simple returns over the last
+'window' bars:
+
+	r[i]  = price[i] / price[i-1] - 1
+	Beta  = cov(r_asset, r_benchmark) / var(r_benchmark)
+
+Beta > 1 means the asset amplifies benchmark moves, 0 < Beta < 1 dampens them,
+and a negative Beta moves against the benchmark.
+-----------------------------------------
+*/
+type Beta struct {
+	Window int
+}
+
+// NewBeta returns a Beta measured over the given number of returns (e.g. 60).
+func NewBeta(window int) *Beta {
+	return &Beta{Window: window}
+}
+
+// Calculate expects asset and benchmark prices of equal length (see AlignByTime).
+// Window returns need Window+1 prices, so the first Window values are math.NaN(),
+// as are windows where the benchmark returns are constant.
+func (b *Beta) Calculate(asset, benchmark []float64) ([]float64, error) {
+	if len(asset) != len(benchmark) {
+		return nil, errors.New("asset and benchmark must have the same length")
+	}
+	if b.Window < 2 {
+		return nil, errors.New("window must be >= 2 for Beta")
+	}
+	n := len(asset)
+	if n <= b.Window {
+		return nil, errors.New("not enough data for Beta")
+	}
+
+	ra := simpleReturns(asset)
+	rb := simpleReturns(benchmark)
+
+	out := make([]float64, n)
+	for i := 0; i < b.Window; i++ {
+		out[i] = math.NaN()
+	}
+	for i := b.Window; i < n; i++ {
+		// returns[k] belongs to price index k+1
+		xs := rb[i-b.Window : i]
+		ys := ra[i-b.Window : i]
+		v := stat.Variance(xs, nil)
+		if v == 0 {
+			out[i] = math.NaN()
+			continue
+		}
+		out[i] = stat.Covariance(ys, xs, nil) / v
+	}
+	return out, nil
+}
+
+// simpleReturns returns price[i]/price[i-1] - 1 for i >= 1 (length n-1).
+func simpleReturns(prices []float64) []float64 {
+	out := make([]float64, len(prices)-1)
+	for i := 1; i < len(prices); i++ {
+		out[i-1] = prices[i]/prices[i-1] - 1
+	}
+	return out
+}
@@ -2,6 +2,7 @@ package indicators
 
 import (
 	"errors"
+	"math"
 
 	"gonum.org/v1/gonum/stat"
 )
@@ -38,3 +39,76 @@ func (b *BollingerBands) Calculate(prices []float64) ([]float64, []float64, []fl
 	}
 	return mid, up, low, nil
 }
+
+// CalculatePercentB returns %B, the position of price within the bands:
+//
+//	%B = (price - lower) / (upper - lower)
+//
+// 1 means price is on the upper band, 0 on the lower band; values outside [0,1]
+// are outside the bands. Warm-up bars and bars with zero-width bands are math.NaN().
+func (b *BollingerBands) CalculatePercentB(prices []float64) ([]float64, error) {
+	_, up, low, err := b.Calculate(prices)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]float64, len(prices))
+	for i := range prices {
+		width := up[i] - low[i]
+		if i < b.Window-1 || width == 0 {
+			out[i] = math.NaN()
+		} else {
+			out[i] = (prices[i] - low[i]) / width
+		}
+	}
+	return out, nil
+}
+
+// CalculateBandWidth returns the BandWidth, the band spread relative to the middle band:
+//
+//	BandWidth = (upper - lower) / middle
+//
+// Low readings mark a volatility contraction ("squeeze"). Warm-up bars are math.NaN().
+func (b *BollingerBands) CalculateBandWidth(prices []float64) ([]float64, error) {
+	mid, up, low, err := b.Calculate(prices)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]float64, len(prices))
+	for i := range prices {
+		if i < b.Window-1 || mid[i] == 0 {
+			out[i] = math.NaN()
+		} else {
+			out[i] = (up[i] - low[i]) / mid[i]
+		}
+	}
+	return out, nil
+}
+
+// BollingerBandsStream computes the bands one price at a time. After warm-up it
+// matches Calculate; the first Window-1 values are math.NaN() where Calculate reports 0.
+type BollingerBandsStream struct {
+	cfg    BollingerBands
+	window []float64
+}
+
+// NewStream returns a BollingerBandsStream with this configuration.
+func (b *BollingerBands) NewStream() (*BollingerBandsStream, error) {
+	if b.Window < 1 {
+		return nil, errors.New("window must be >= 1 for BollingerBands")
+	}
+	return &BollingerBandsStream{cfg: *b, window: make([]float64, 0, b.Window)}, nil
+}
+
+// Update adds a price and returns the middle, upper and lower bands.
+func (st *BollingerBandsStream) Update(price float64) (float64, float64, float64) {
+	if len(st.window) == st.cfg.Window {
+		st.window = append(st.window[:0], st.window[1:]...)
+	}
+	st.window = append(st.window, price)
+	if len(st.window) < st.cfg.Window {
+		return math.NaN(), math.NaN(), math.NaN()
+	}
+	mean := stat.Mean(st.window, nil)
+	std := stat.StdDev(st.window, nil)
+	return mean, mean + st.cfg.NumStd*std, mean - st.cfg.NumStd*std
+}
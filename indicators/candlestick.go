@@ -0,0 +1,468 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+/*
+Candlestick Pattern Recognition:
+-----------------------------------------
+Each pattern returns a per-bar score in the style of TA-Lib's CDL functions:
++100 on the last bar of a bullish pattern, -100 on a bearish one and 0 otherwise.
+Doji is direction-neutral and scores +100.
+
+"Long" and "short" are measured against a reference range: the ATR or the
+average high-low range of the bars before the current one, so a pattern is judged
+against recent volatility rather than absolute prices. With ref = reference range,
+body = |close - open| and shadows measured from the body:
+
+	Doji:              body <= DojiBody * ref
+	Short body:        body <= ShortBody * ref
+	Long body:         body >= LongBody * ref
+	Long shadow:       shadow >= LongShadow * body
+	Short shadow:      shadow <= ShortShadow * ref
+
+Hammer-type patterns depend on the preceding trend, taken as the sign of
+close[k-1] - close[k-1-TrendWindow] before the first bar k of the pattern
+(TrendWindow = 0 ignores the trend).
+
+Patterns:
+  - Doji
+  - Hammer (+) / Hanging Man (-):         short body, long lower and short upper shadow
+  - Inverted Hammer (+) / Shooting Star (-): short body, long upper and short lower shadow
+  - Engulfing (+/-):        body engulfs the previous opposite-colored body
+  - Harami (+/-):           short body inside the previous long opposite-colored body
+  - Piercing (+) / Dark Cloud Cover (-): opens beyond the prior extreme, closes past its midpoint
+  - Morning Star (+) / Evening Star (-): long candle, gapped short body, strong reversal candle
+  - Three White Soldiers (+) / Three Black Crows (-): three long candles stepping in one direction
+-----------------------------------------
+*/
+
+// CandlePattern identifies a candlestick pattern.
+type CandlePattern int
+
+const (
+	PatternDoji CandlePattern = iota
+	PatternHammer
+	PatternHangingMan
+	PatternInvertedHammer
+	PatternShootingStar
+	PatternEngulfing
+	PatternHarami
+	PatternPiercing
+	PatternDarkCloudCover
+	PatternMorningStar
+	PatternEveningStar
+	PatternThreeWhiteSoldiers
+	PatternThreeBlackCrows
+)
+
+// AllCandlePatterns lists every pattern in the order they are defined.
+var AllCandlePatterns = []CandlePattern{
+	PatternDoji, PatternHammer, PatternHangingMan, PatternInvertedHammer, PatternShootingStar,
+	PatternEngulfing, PatternHarami, PatternPiercing, PatternDarkCloudCover,
+	PatternMorningStar, PatternEveningStar, PatternThreeWhiteSoldiers, PatternThreeBlackCrows,
+}
+
+var candlePatternNames = map[CandlePattern]string{
+	PatternDoji:               "doji",
+	PatternHammer:             "hammer",
+	PatternHangingMan:         "hanging man",
+	PatternInvertedHammer:     "inverted hammer",
+	PatternShootingStar:       "shooting star",
+	PatternEngulfing:          "engulfing",
+	PatternHarami:             "harami",
+	PatternPiercing:           "piercing",
+	PatternDarkCloudCover:     "dark cloud cover",
+	PatternMorningStar:        "morning star",
+	PatternEveningStar:        "evening star",
+	PatternThreeWhiteSoldiers: "three white soldiers",
+	PatternThreeBlackCrows:    "three black crows",
+}
+
+func (p CandlePattern) String() string {
+	if name, ok := candlePatternNames[p]; ok {
+		return name
+	}
+	return "unknown pattern"
+}
+
+// CandleRange selects the reference range that candle sizes are compared against.
+type CandleRange int
+
+const (
+	// CandleRangeATR uses the Average True Range, so gaps count towards volatility.
+	CandleRangeATR CandleRange = iota
+	// CandleRangeAverage uses the simple average of high - low.
+	CandleRangeAverage
+)
+
+// CandlestickPatterns detects candlestick patterns. Use NewCandlestickPatterns for
+// the default thresholds and adjust fields as needed.
+type CandlestickPatterns struct {
+	RangeWindow int         // bars in the reference range (e.g. 14)
+	Range       CandleRange // ATR or average high-low range
+	TrendWindow int         // bars used to judge the preceding trend; 0 ignores trend
+
+	DojiBody    float64 // max body of a doji, as a fraction of the reference range
+	ShortBody   float64 // max short body, as a fraction of the reference range
+	LongBody    float64 // min long body, as a fraction of the reference range
+	LongShadow  float64 // min long shadow, as a multiple of the body
+	ShortShadow float64 // max short shadow, as a fraction of the reference range
+}
+
+// CandlestickResult holds the per-pattern scores and their per-bar sum.
+type CandlestickResult struct {
+	Patterns map[CandlePattern][]int
+	Score    []int // sum of all pattern scores: > 0 is net bullish, < 0 net bearish
+}
+
+// NewCandlestickPatterns returns a detector with commonly used thresholds:
+// a 14-bar ATR reference, 5-bar trend, doji body 10%, short body 30%, long body 60%
+// of the range, long shadows twice the body and short shadows 10% of the range.
+func NewCandlestickPatterns() *CandlestickPatterns {
+	return &CandlestickPatterns{
+		RangeWindow: 14,
+		Range:       CandleRangeATR,
+		TrendWindow: 5,
+		DojiBody:    0.1,
+		ShortBody:   0.3,
+		LongBody:    0.6,
+		LongShadow:  2.0,
+		ShortShadow: 0.1,
+	}
+}
+
+// Detect returns the score series of a single pattern for open, high, low, close
+// slices of equal length.
+func (c *CandlestickPatterns) Detect(pattern CandlePattern, open, high, low, close []float64) ([]int, error) {
+	s, err := c.newCandleSeries(open, high, low, close)
+	if err != nil {
+		return nil, err
+	}
+	return s.detect(pattern)
+}
+
+// Calculate scans every pattern in AllCandlePatterns.
+func (c *CandlestickPatterns) Calculate(open, high, low, close []float64) (*CandlestickResult, error) {
+	s, err := c.newCandleSeries(open, high, low, close)
+	if err != nil {
+		return nil, err
+	}
+	res := &CandlestickResult{
+		Patterns: make(map[CandlePattern][]int, len(AllCandlePatterns)),
+		Score:    make([]int, len(close)),
+	}
+	for _, p := range AllCandlePatterns {
+		scores, err := s.detect(p)
+		if err != nil {
+			return nil, err
+		}
+		res.Patterns[p] = scores
+		for i, v := range scores {
+			res.Score[i] += v
+		}
+	}
+	return res, nil
+}
+
+// candleSeries holds the inputs and the reference range for one scan.
+type candleSeries struct {
+	cfg                    *CandlestickPatterns
+	open, high, low, close []float64
+	ref                    []float64 // reference range of the bars before i; NaN if unknown
+}
+
+func (c *CandlestickPatterns) newCandleSeries(open, high, low, close []float64) (*candleSeries, error) {
+	n := len(close)
+	if len(open) != n || len(high) != n || len(low) != n {
+		return nil, errors.New("open, high, low, and close must have the same length")
+	}
+	if c.RangeWindow < 1 || c.TrendWindow < 0 {
+		return nil, errors.New("invalid candlestick pattern window")
+	}
+	if n <= c.RangeWindow {
+		return nil, errors.New("not enough data for candlestick patterns")
+	}
+
+	var rng []float64
+	switch c.Range {
+	case CandleRangeATR:
+		atr, err := NewATR(c.RangeWindow).Calculate(high, low, close)
+		if err != nil {
+			return nil, err
+		}
+		rng = atr
+		for i := 0; i < c.RangeWindow-1; i++ {
+			rng[i] = math.NaN()
+		}
+	case CandleRangeAverage:
+		hl := make([]float64, n)
+		for i := range hl {
+			hl[i] = high[i] - low[i]
+		}
+		rng = rollingMean(hl, 0, c.RangeWindow)
+	default:
+		return nil, errors.New("unknown candle range")
+	}
+
+	// bar i is judged against the range of the bars before it
+	ref := make([]float64, n)
+	ref[0] = math.NaN()
+	copy(ref[1:], rng[:n-1])
+
+	return &candleSeries{cfg: c, open: open, high: high, low: low, close: close, ref: ref}, nil
+}
+
+func (s *candleSeries) detect(pattern CandlePattern) ([]int, error) {
+	var match func(i int) int
+	switch pattern {
+	case PatternDoji:
+		match = s.doji
+	case PatternHammer:
+		match = func(i int) int { return s.hammerShape(i, 1, 100) }
+	case PatternHangingMan:
+		match = func(i int) int { return s.hammerShape(i, -1, -100) }
+	case PatternInvertedHammer:
+		match = func(i int) int { return s.invertedShape(i, 1, 100) }
+	case PatternShootingStar:
+		match = func(i int) int { return s.invertedShape(i, -1, -100) }
+	case PatternEngulfing:
+		match = s.engulfing
+	case PatternHarami:
+		match = s.harami
+	case PatternPiercing:
+		match = s.piercing
+	case PatternDarkCloudCover:
+		match = s.darkCloudCover
+	case PatternMorningStar:
+		match = func(i int) int { return s.star(i, 1) }
+	case PatternEveningStar:
+		match = func(i int) int { return s.star(i, -1) }
+	case PatternThreeWhiteSoldiers:
+		match = func(i int) int { return s.threeLine(i, 1) }
+	case PatternThreeBlackCrows:
+		match = func(i int) int { return s.threeLine(i, -1) }
+	default:
+		return nil, errors.New("unknown candlestick pattern")
+	}
+
+	out := make([]int, len(s.close))
+	for i := range out {
+		if !math.IsNaN(s.ref[i]) && s.ref[i] > 0 {
+			out[i] = match(i)
+		}
+	}
+	return out, nil
+}
+
+func (s *candleSeries) body(i int) float64 { return math.Abs(s.close[i] - s.open[i]) }
+
+func (s *candleSeries) upperShadow(i int) float64 {
+	return s.high[i] - math.Max(s.open[i], s.close[i])
+}
+
+func (s *candleSeries) lowerShadow(i int) float64 {
+	return math.Min(s.open[i], s.close[i]) - s.low[i]
+}
+
+// color returns 1 for a white (bullish) candle, -1 for a black one and 0 for open == close.
+func (s *candleSeries) color(i int) int {
+	switch {
+	case s.close[i] > s.open[i]:
+		return 1
+	case s.close[i] < s.open[i]:
+		return -1
+	}
+	return 0
+}
+
+func (s *candleSeries) isLong(i int) bool  { return s.body(i) >= s.cfg.LongBody*s.ref[i] }
+func (s *candleSeries) isShort(i int) bool { return s.body(i) <= s.cfg.ShortBody*s.ref[i] }
+
+// trendBefore reports the trend leading into bar k: 1 up, -1 down, 0 flat or unknown.
+// With TrendWindow = 0 it returns want, so trend-dependent patterns always qualify.
+func (s *candleSeries) trendBefore(k, want int) int {
+	t := s.cfg.TrendWindow
+	if t == 0 {
+		return want
+	}
+	if k-1-t < 0 {
+		return 0
+	}
+	switch d := s.close[k-1] - s.close[k-1-t]; {
+	case d > 0:
+		return 1
+	case d < 0:
+		return -1
+	}
+	return 0
+}
+
+func (s *candleSeries) doji(i int) int {
+	if s.body(i) <= s.cfg.DojiBody*s.ref[i] {
+		return 100
+	}
+	return 0
+}
+
+// hammerShape matches a long lower shadow candle that reverses the preceding trend:
+// reversal = 1 is a hammer after a downtrend, -1 a hanging man after an uptrend.
+func (s *candleSeries) hammerShape(i, reversal, score int) int {
+	if i < 1 || !s.isShort(i) {
+		return 0
+	}
+	if s.lowerShadow(i) < s.cfg.LongShadow*s.body(i) || s.lowerShadow(i) <= s.cfg.ShortShadow*s.ref[i] {
+		return 0
+	}
+	if s.upperShadow(i) > s.cfg.ShortShadow*s.ref[i] {
+		return 0
+	}
+	if s.trendBefore(i, -reversal) != -reversal {
+		return 0
+	}
+	return score
+}
+
+// invertedShape matches a long upper shadow candle: an inverted hammer after a
+// downtrend or a shooting star after an uptrend.
+func (s *candleSeries) invertedShape(i, reversal, score int) int {
+	if i < 1 || !s.isShort(i) {
+		return 0
+	}
+	if s.upperShadow(i) < s.cfg.LongShadow*s.body(i) || s.upperShadow(i) <= s.cfg.ShortShadow*s.ref[i] {
+		return 0
+	}
+	if s.lowerShadow(i) > s.cfg.ShortShadow*s.ref[i] {
+		return 0
+	}
+	if s.trendBefore(i, -reversal) != -reversal {
+		return 0
+	}
+	return score
+}
+
+func (s *candleSeries) engulfing(i int) int {
+	if i < 1 {
+		return 0
+	}
+	p := i - 1
+	o, c, po, pc := s.open[i], s.close[i], s.open[p], s.close[p]
+	switch {
+	case s.color(p) == -1 && s.color(i) == 1 && o <= pc && c >= po && (o < pc || c > po):
+		return 100
+	case s.color(p) == 1 && s.color(i) == -1 && o >= pc && c <= po && (o > pc || c < po):
+		return -100
+	}
+	return 0
+}
+
+func (s *candleSeries) harami(i int) int {
+	if i < 1 {
+		return 0
+	}
+	p := i - 1
+	if !s.isLong(p) || !s.isShort(i) {
+		return 0
+	}
+	if math.Max(s.open[i], s.close[i]) > math.Max(s.open[p], s.close[p]) ||
+		math.Min(s.open[i], s.close[i]) < math.Min(s.open[p], s.close[p]) {
+		return 0
+	}
+	switch {
+	case s.color(p) == -1 && s.color(i) != -1:
+		return 100
+	case s.color(p) == 1 && s.color(i) != 1:
+		return -100
+	}
+	return 0
+}
+
+func (s *candleSeries) piercing(i int) int {
+	if i < 1 {
+		return 0
+	}
+	p := i - 1
+	if s.color(p) != -1 || s.color(i) != 1 || !s.isLong(p) || !s.isLong(i) {
+		return 0
+	}
+	mid := (s.open[p] + s.close[p]) / 2
+	if s.open[i] < s.low[p] && s.close[i] > mid && s.close[i] < s.open[p] {
+		return 100
+	}
+	return 0
+}
+
+func (s *candleSeries) darkCloudCover(i int) int {
+	if i < 1 {
+		return 0
+	}
+	p := i - 1
+	if s.color(p) != 1 || s.color(i) != -1 || !s.isLong(p) || !s.isLong(i) {
+		return 0
+	}
+	mid := (s.open[p] + s.close[p]) / 2
+	if s.open[i] > s.high[p] && s.close[i] < mid && s.close[i] > s.open[p] {
+		return -100
+	}
+	return 0
+}
+
+// star matches a morning star (dir = 1) or evening star (dir = -1) ending at bar i.
+func (s *candleSeries) star(i, dir int) int {
+	if i < 2 {
+		return 0
+	}
+	first, mid := i-2, i-1
+	if s.color(first) != -dir || !s.isLong(first) || !s.isShort(mid) || s.color(i) != dir {
+		return 0
+	}
+	if s.body(i) <= s.cfg.ShortBody*s.ref[i] {
+		return 0
+	}
+	firstMid := (s.open[first] + s.close[first]) / 2
+	if dir == 1 {
+		// star body gaps below the first body; third candle closes into its upper half
+		if math.Max(s.open[mid], s.close[mid]) < s.close[first] && s.close[i] > firstMid {
+			return 100
+		}
+	} else {
+		if math.Min(s.open[mid], s.close[mid]) > s.close[first] && s.close[i] < firstMid {
+			return -100
+		}
+	}
+	return 0
+}
+
+// threeLine matches three white soldiers (dir = 1) or three black crows (dir = -1)
+// ending at bar i: three long candles of the same color, each opening within the
+// previous body and closing further in the direction, with short closing shadows.
+func (s *candleSeries) threeLine(i, dir int) int {
+	if i < 2 {
+		return 0
+	}
+	for k := i - 2; k <= i; k++ {
+		if s.color(k) != dir || !s.isLong(k) {
+			return 0
+		}
+		shadow := s.upperShadow(k)
+		if dir == -1 {
+			shadow = s.lowerShadow(k)
+		}
+		if shadow > s.cfg.ShortShadow*s.ref[k] {
+			return 0
+		}
+		if k > i-2 {
+			lo := math.Min(s.open[k-1], s.close[k-1])
+			hi := math.Max(s.open[k-1], s.close[k-1])
+			if s.open[k] < lo || s.open[k] > hi {
+				return 0
+			}
+			if float64(dir)*(s.close[k]-s.close[k-1]) <= 0 {
+				return 0
+			}
+		}
+	}
+	return 100 * dir
+}
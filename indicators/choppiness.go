@@ -0,0 +1,65 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+/*
+Choppiness Index (CHOP):
+-----------------------------------------
+Developed by E.W. Dreiss. Compares the summed True Range to the overall
+high-low range of the window, on a log scale:
+
+	CHOP = 100 * log10( sum(TR, period) / (highest high - lowest low) ) / log10(period)
+
+Values near 100 indicate sideways, choppy markets; values near 0 indicate a strong trend.
+Common thresholds are 61.8 (choppy) and 38.2 (trending). A typical period is 14.
+-----------------------------------------
+*/
+type ChoppinessIndex struct {
+	Period int
+}
+
+// NewChoppinessIndex returns a ChoppinessIndex instance with the given period.
+func NewChoppinessIndex(period int) *ChoppinessIndex {
+	return &ChoppinessIndex{Period: period}
+}
+
+// Calculate returns a slice of CHOP values, the same length as the inputs.
+// The first (Period-1) values are math.NaN(), as is any bar whose window has no range.
+func (c *ChoppinessIndex) Calculate(highs, lows, closes []float64) ([]float64, error) {
+	n := len(highs)
+	if n != len(lows) || n != len(closes) {
+		return nil, errors.New("highs, lows, and closes must have the same length")
+	}
+	if c.Period < 2 {
+		return nil, errors.New("period must be >= 2 for Choppiness Index")
+	}
+	if n < c.Period {
+		return nil, errors.New("not enough data for Choppiness Index")
+	}
+
+	tr := trueRange(highs, lows, closes)
+	chop := make([]float64, n)
+	for i := 0; i < c.Period-1; i++ {
+		chop[i] = math.NaN()
+	}
+
+	logPeriod := math.Log10(float64(c.Period))
+	for i := c.Period - 1; i < n; i++ {
+		start := i - c.Period + 1
+		var sumTR float64
+		for j := start; j <= i; j++ {
+			sumTR += tr[j]
+		}
+		lowV, highV := lowestHighest(highs, lows, start, i)
+		if highV-lowV == 0 {
+			chop[i] = math.NaN()
+		} else {
+			chop[i] = 100 * math.Log10(sumTR/(highV-lowV)) / logPeriod
+		}
+	}
+
+	return chop, nil
+}
@@ -0,0 +1,61 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+/*
+CMO (Chande Momentum Oscillator):
+-----------------------------------------
+Developed by Tushar Chande. Over the last 'window' price changes:
+
+	SumUp   = sum of positive changes
+	SumDown = sum of absolute negative changes
+	CMO     = 100 * (SumUp - SumDown) / (SumUp + SumDown)
+
+Unlike RSI, the sums are not smoothed, and the result ranges from -100 to +100.
+-----------------------------------------
+*/
+type CMO struct {
+	Window int
+}
+
+// NewCMO returns a CMO instance with the given window (often 9, 14 or 20).
+func NewCMO(window int) *CMO {
+	return &CMO{Window: window}
+}
+
+// Calculate returns a slice of CMO values, the same length as prices.
+// The first 'Window' values are math.NaN(); a flat window yields 0.
+func (c *CMO) Calculate(prices []float64) ([]float64, error) {
+	if c.Window < 1 {
+		return nil, errors.New("window must be >= 1 for CMO")
+	}
+	if len(prices) <= c.Window {
+		return nil, errors.New("not enough data for CMO")
+	}
+
+	out := make([]float64, len(prices))
+	for i := 0; i < c.Window; i++ {
+		out[i] = math.NaN()
+	}
+	for i := c.Window; i < len(prices); i++ {
+		var sumUp, sumDown float64
+		for j := i - c.Window + 1; j <= i; j++ {
+			diff := prices[j] - prices[j-1]
+			if diff > 0 {
+				sumUp += diff
+			} else {
+				sumDown -= diff
+			}
+		}
+		total := sumUp + sumDown
+		if total == 0 {
+			out[i] = 0
+		} else {
+			out[i] = 100 * (sumUp - sumDown) / total
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,105 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+/*
+Connors RSI (CRSI):
+-----------------------------------------
+Developed by Larry Connors for short-term mean reversion. It averages three components:
+
+	RSI         = RSI(Close, rsiPeriod), typically RSI(3)
+	StreakRSI   = RSI(Streak, streakPeriod), typically RSI(2)
+	PercentRank = share of the previous rankPeriod ROC(1) values below today's, typically 100 bars
+	CRSI        = (RSI + StreakRSI + PercentRank) / 3
+
+Streak counts consecutive up closes (1, 2, 3, ...) or down closes (-1, -2, ...),
+and resets to 0 when the close is unchanged.
+-----------------------------------------
+*/
+type ConnorsRSI struct {
+	RSIPeriod    int
+	StreakPeriod int
+	RankPeriod   int
+}
+
+// NewConnorsRSI returns a ConnorsRSI instance, commonly NewConnorsRSI(3, 2, 100).
+func NewConnorsRSI(rsiPeriod, streakPeriod, rankPeriod int) *ConnorsRSI {
+	return &ConnorsRSI{
+		RSIPeriod:    rsiPeriod,
+		StreakPeriod: streakPeriod,
+		RankPeriod:   rankPeriod,
+	}
+}
+
+// Calculate returns a slice of Connors RSI values in [0..100], the same length as prices.
+// Values are math.NaN() until all three components are available.
+func (c *ConnorsRSI) Calculate(prices []float64) ([]float64, error) {
+	if c.RSIPeriod < 1 || c.StreakPeriod < 1 || c.RankPeriod < 1 {
+		return nil, errors.New("periods must be >= 1 for Connors RSI")
+	}
+	first := c.RSIPeriod
+	if c.StreakPeriod > first {
+		first = c.StreakPeriod
+	}
+	if c.RankPeriod+1 > first {
+		first = c.RankPeriod + 1
+	}
+	n := len(prices)
+	if n <= first {
+		return nil, errors.New("not enough data for Connors RSI")
+	}
+
+	priceRSI, err := NewRSI(c.RSIPeriod).Calculate(prices)
+	if err != nil {
+		return nil, err
+	}
+	streakRSI, err := NewRSI(c.StreakPeriod).Calculate(streaks(prices))
+	if err != nil {
+		return nil, err
+	}
+	roc, err := NewROC(1).Calculate(prices)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]float64, n)
+	for i := 0; i < first; i++ {
+		out[i] = math.NaN()
+	}
+	for i := first; i < n; i++ {
+		var below int
+		for j := i - c.RankPeriod; j < i; j++ {
+			if roc[j] < roc[i] {
+				below++
+			}
+		}
+		rank := 100 * float64(below) / float64(c.RankPeriod)
+		out[i] = (priceRSI[i] + streakRSI[i] + rank) / 3
+	}
+	return out, nil
+}
+
+// streaks returns the signed length of the current run of up or down closes at each bar.
+func streaks(prices []float64) []float64 {
+	out := make([]float64, len(prices))
+	for i := 1; i < len(prices); i++ {
+		switch {
+		case prices[i] > prices[i-1]:
+			if out[i-1] > 0 {
+				out[i] = out[i-1] + 1
+			} else {
+				out[i] = 1
+			}
+		case prices[i] < prices[i-1]:
+			if out[i-1] < 0 {
+				out[i] = out[i-1] - 1
+			} else {
+				out[i] = -1
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,105 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/stat"
+)
+
+/*
+Rolling Correlation:
+-----------------------------------------
+Correlation between two aligned series over the last 'window' bars.
+
+Pearson:   cov(x, y) / (stddev(x) * stddev(y))
+Spearman:  Pearson correlation of the ranks of x and y within the window
+           (tied values share their average rank)
+
+Values lie in [-1, 1]. Correlating returns rather than raw prices avoids the
+spuriously high readings two trending price series produce.
+-----------------------------------------
+*/
+
+// CorrelationMethod selects the correlation coefficient used by RollingCorrelation.
+type CorrelationMethod int
+
+const (
+	CorrelationPearson CorrelationMethod = iota
+	CorrelationSpearman
+)
+
+// RollingCorrelation computes the correlation of two series over a rolling window.
+type RollingCorrelation struct {
+	Window int
+	Method CorrelationMethod
+}
+
+// NewRollingCorrelation returns a RollingCorrelation with the given window and method.
+func NewRollingCorrelation(window int, method CorrelationMethod) *RollingCorrelation {
+	return &RollingCorrelation{Window: window, Method: method}
+}
+
+// Calculate expects two series of equal length (see AlignByTime) and returns the rolling
+// correlation. The first (Window-1) values, and windows where either series is constant,
+// are math.NaN().
+func (c *RollingCorrelation) Calculate(x, y []float64) ([]float64, error) {
+	if len(x) != len(y) {
+		return nil, errors.New("x and y must have the same length")
+	}
+	if c.Window < 2 {
+		return nil, errors.New("window must be >= 2 for RollingCorrelation")
+	}
+	if len(x) < c.Window {
+		return nil, errors.New("not enough data for RollingCorrelation")
+	}
+	if c.Method != CorrelationPearson && c.Method != CorrelationSpearman {
+		return nil, errors.New("unknown correlation method")
+	}
+
+	out := make([]float64, len(x))
+	for i := 0; i < c.Window-1; i++ {
+		out[i] = math.NaN()
+	}
+	for i := c.Window - 1; i < len(x); i++ {
+		xs := x[i-c.Window+1 : i+1]
+		ys := y[i-c.Window+1 : i+1]
+		if c.Method == CorrelationSpearman {
+			xs, ys = ranks(xs), ranks(ys)
+		}
+		out[i] = pearson(xs, ys)
+	}
+	return out, nil
+}
+
+// pearson returns the Pearson correlation of xs and ys, or NaN if either is constant.
+func pearson(xs, ys []float64) float64 {
+	if stat.Variance(xs, nil) == 0 || stat.Variance(ys, nil) == 0 {
+		return math.NaN()
+	}
+	return stat.Correlation(xs, ys, nil)
+}
+
+// ranks returns the 1-based rank of each value, giving ties their average rank.
+func ranks(values []float64) []float64 {
+	idx := make([]int, len(values))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return values[idx[a]] < values[idx[b]] })
+
+	out := make([]float64, len(values))
+	for start := 0; start < len(idx); {
+		end := start
+		for end+1 < len(idx) && values[idx[end+1]] == values[idx[start]] {
+			end++
+		}
+		rank := float64(start+end)/2 + 1
+		for k := start; k <= end; k++ {
+			out[idx[k]] = rank
+		}
+		start = end + 1
+	}
+	return out
+}
@@ -0,0 +1,172 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/stat"
+)
+
+/*
+Divergence Detection:
+-----------------------------------------
+Compares consecutive price swing points with the oscillator values at the same bars.
+Swings are found as in SupportResistance/Fractals: a high above the 'Left' bars before
+and 'Right' bars after it (lows likewise), so each divergence is only known Right bars
+after its second swing, at ConfirmedIndex.
+
+                      Price            Oscillator
+Regular bearish:      higher high      lower high      (uptrend losing momentum)
+Hidden bearish:       lower high       higher high     (downtrend continuation)
+Regular bullish:      lower low        higher low      (downtrend losing momentum)
+Hidden bullish:       higher low       lower low       (uptrend continuation)
+
+Strength is the oscillator move between the two swings divided by the standard
+deviation of the oscillator over that span, so it is comparable across oscillators
+with different scales (RSI, MACD histogram, CCI...).
+-----------------------------------------
+*/
+
+// DivergenceKind identifies the type of divergence.
+type DivergenceKind int
+
+const (
+	DivergenceRegularBullish DivergenceKind = iota
+	DivergenceRegularBearish
+	DivergenceHiddenBullish
+	DivergenceHiddenBearish
+)
+
+func (k DivergenceKind) String() string {
+	switch k {
+	case DivergenceRegularBullish:
+		return "regular bullish"
+	case DivergenceRegularBearish:
+		return "regular bearish"
+	case DivergenceHiddenBullish:
+		return "hidden bullish"
+	case DivergenceHiddenBearish:
+		return "hidden bearish"
+	}
+	return "unknown divergence"
+}
+
+// Bullish reports whether the divergence points to higher prices.
+func (k DivergenceKind) Bullish() bool {
+	return k == DivergenceRegularBullish || k == DivergenceHiddenBullish
+}
+
+// Divergence is one divergence between two swing points.
+type Divergence struct {
+	Kind           DivergenceKind
+	StartIndex     int // bar of the first swing
+	EndIndex       int // bar of the second swing
+	ConfirmedIndex int // bar on which the second swing became known (EndIndex + Right)
+	PriceStart     float64
+	PriceEnd       float64
+	OscStart       float64
+	OscEnd         float64
+	Strength       float64
+}
+
+// DivergenceDetector finds divergences between price and an oscillator.
+type DivergenceDetector struct {
+	Left    int // bars before a swing point (e.g. 5)
+	Right   int // bars after a swing point (e.g. 5); also the confirmation delay
+	MinBars int // min bars between the two swings (e.g. 5)
+	MaxBars int // max bars between the two swings (e.g. 60); 0 means no limit
+	Hidden  bool
+}
+
+// NewDivergenceDetector returns a detector for regular divergences with the given swing
+// window and range of bars between swings. Set Hidden to also report hidden divergences.
+func NewDivergenceDetector(left, right, minBars, maxBars int) *DivergenceDetector {
+	return &DivergenceDetector{Left: left, Right: right, MinBars: minBars, MaxBars: maxBars}
+}
+
+// Calculate expects highs, lows and an oscillator series of equal length (for a single
+// price series pass it as both highs and lows). Oscillator warm-up values may be
+// math.NaN(); swings where the oscillator is NaN are skipped. Divergences are returned
+// in order of ConfirmedIndex.
+func (d *DivergenceDetector) Calculate(highs, lows, osc []float64) ([]Divergence, error) {
+	if len(highs) != len(lows) || len(lows) != len(osc) {
+		return nil, errors.New("highs, lows, and oscillator must have the same length")
+	}
+	if d.Left < 1 || d.Right < 1 || d.MinBars < 0 || d.MaxBars < 0 {
+		return nil, errors.New("invalid divergence parameters")
+	}
+	if len(highs) < d.Left+d.Right+1 {
+		return nil, errors.New("not enough data for divergence detection")
+	}
+
+	swingHighs, swingLows := swingPoints(highs, lows, d.Left, d.Right)
+	var out []Divergence
+
+	// pairs walks consecutive swings of one type and classifies each pair.
+	// dir is 1 for swing highs (bearish divergences) and -1 for swing lows (bullish).
+	pairs := func(swings []int, price []float64, dir int) {
+		prev := -1
+		for _, i := range swings {
+			if math.IsNaN(osc[i]) {
+				continue
+			}
+			if prev >= 0 {
+				bars := i - prev
+				if bars >= d.MinBars && (d.MaxBars == 0 || bars <= d.MaxBars) {
+					if div, ok := d.classify(prev, i, price, osc, dir); ok {
+						out = append(out, div)
+					}
+				}
+			}
+			prev = i
+		}
+	}
+	pairs(swingHighs, highs, 1)
+	pairs(swingLows, lows, -1)
+
+	sort.SliceStable(out, func(i, j int) bool { return out[i].ConfirmedIndex < out[j].ConfirmedIndex })
+	return out, nil
+}
+
+func (d *DivergenceDetector) classify(a, b int, price, osc []float64, dir int) (Divergence, bool) {
+	dp := price[b] - price[a]
+	do := osc[b] - osc[a]
+
+	var kind DivergenceKind
+	switch {
+	case dir == 1 && dp > 0 && do < 0:
+		kind = DivergenceRegularBearish
+	case dir == 1 && dp < 0 && do > 0 && d.Hidden:
+		kind = DivergenceHiddenBearish
+	case dir == -1 && dp < 0 && do > 0:
+		kind = DivergenceRegularBullish
+	case dir == -1 && dp > 0 && do < 0 && d.Hidden:
+		kind = DivergenceHiddenBullish
+	default:
+		return Divergence{}, false
+	}
+
+	var span []float64
+	for _, v := range osc[a : b+1] {
+		if !math.IsNaN(v) {
+			span = append(span, v)
+		}
+	}
+	strength := 0.0
+	if sd := stat.StdDev(span, nil); sd > 0 {
+		strength = math.Abs(do) / sd
+	}
+
+	return Divergence{
+		Kind:           kind,
+		StartIndex:     a,
+		EndIndex:       b,
+		ConfirmedIndex: b + d.Right,
+		PriceStart:     price[a],
+		PriceEnd:       price[b],
+		OscStart:       osc[a],
+		OscEnd:         osc[b],
+		Strength:       strength,
+	}, true
+}
@@ -0,0 +1,54 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+// DonchianChannels tracks the highest high and lowest low over a rolling window,
+// with a midline halfway between them.
+type DonchianChannels struct {
+	Window int
+}
+
+// NewDonchianChannels creates a DonchianChannels instance with the given window (often 20).
+func NewDonchianChannels(window int) *DonchianChannels {
+	return &DonchianChannels{Window: window}
+}
+
+// Calculate returns three slices (middle, upper, lower), each the same length as the inputs.
+// - upper line  = highest high over the last Window bars (including the current bar)
+// - lower line  = lowest low over the last Window bars
+// - middle line = (upper + lower) / 2
+//
+// The first (Window-1) values are math.NaN(). Because the current bar is part of the window,
+// breakout rules usually compare price to the previous bar's channel (upper[i-1], lower[i-1]).
+func (d *DonchianChannels) Calculate(high, low []float64) ([]float64, []float64, []float64, error) {
+	length := len(high)
+	if length != len(low) {
+		return nil, nil, nil, errors.New("high and low must have the same length")
+	}
+	if d.Window < 1 {
+		return nil, nil, nil, errors.New("window must be >= 1 for DonchianChannels")
+	}
+	if length < d.Window {
+		return nil, nil, nil, errors.New("not enough data for DonchianChannels")
+	}
+
+	middle := make([]float64, length)
+	upper := make([]float64, length)
+	lower := make([]float64, length)
+	for i := 0; i < d.Window-1; i++ {
+		middle[i] = math.NaN()
+		upper[i] = math.NaN()
+		lower[i] = math.NaN()
+	}
+	for i := d.Window - 1; i < length; i++ {
+		lowV, highV := lowestHighest(high, low, i-d.Window+1, i)
+		upper[i] = highV
+		lower[i] = lowV
+		middle[i] = (highV + lowV) / 2.0
+	}
+
+	return middle, upper, lower, nil
+}
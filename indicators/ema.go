@@ -2,6 +2,7 @@ package indicators
 
 import (
 	"errors"
+	"math"
 )
 
 type EMA struct {
@@ -24,3 +25,35 @@ func (e *EMA) Calculate(prices []float64) ([]float64, error) {
 	}
 	return out, nil
 }
+
+// EMAStream computes the EMA one value at a time. Like Calculate it is seeded with the
+// first price, but it returns math.NaN() until Window prices have been seen so that
+// early, barely-smoothed values aren't mistaken for a settled average.
+type EMAStream struct {
+	window int
+	k      float64
+	value  float64
+	count  int
+}
+
+// NewStream returns an EMAStream with this EMA's window.
+func (e *EMA) NewStream() (*EMAStream, error) {
+	if e.Window < 1 {
+		return nil, errors.New("window must be >= 1 for EMA")
+	}
+	return &EMAStream{window: e.Window, k: 2.0 / (float64(e.Window) + 1.0)}, nil
+}
+
+// Update adds a price and returns the current EMA.
+func (st *EMAStream) Update(price float64) float64 {
+	if st.count == 0 {
+		st.value = price
+	} else {
+		st.value = (price * st.k) + (st.value * (1.0 - st.k))
+	}
+	st.count++
+	if st.count < st.window {
+		return math.NaN()
+	}
+	return st.value
+}
@@ -0,0 +1,47 @@
+package indicators
+
+import (
+	"errors"
+)
+
+// Envelope draws percentage bands around any moving average, e.g. a 20-bar SMA
+// with bands 2.5% above and below it.
+type Envelope struct {
+	MA      Indicator // moving average for the middle line (SMA, EMA, KAMA, T3, ...)
+	Percent float64   // band offset as a percentage of the middle line, e.g. 2.5
+}
+
+// NewEnvelope creates an Envelope around the given moving average.
+func NewEnvelope(ma Indicator, percent float64) *Envelope {
+	return &Envelope{MA: ma, Percent: percent}
+}
+
+// Calculate returns three slices (middle, upper, lower), each the same length as prices.
+// - middle line = MA of prices
+// - upper line  = middle * (1 + Percent/100)
+// - lower line  = middle * (1 - Percent/100)
+//
+// Warm-up values follow whatever the chosen MA produces.
+func (e *Envelope) Calculate(prices []float64) ([]float64, []float64, []float64, error) {
+	if e.MA == nil {
+		return nil, nil, nil, errors.New("no moving average provided for Envelope")
+	}
+	if e.Percent < 0 {
+		return nil, nil, nil, errors.New("percent must be >= 0 for Envelope")
+	}
+
+	middle, err := e.MA.Calculate(prices)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	upper := make([]float64, len(middle))
+	lower := make([]float64, len(middle))
+	offset := e.Percent / 100.0
+	for i, m := range middle {
+		upper[i] = m * (1 + offset)
+		lower[i] = m * (1 - offset)
+	}
+
+	return middle, upper, lower, nil
+}
@@ -0,0 +1,98 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+/*
+Fisher Transform (John Ehlers):
+-----------------------------------------
+Converts prices into a roughly Gaussian distribution so turning points stand out:
+
+	Price  = (High + Low) / 2
+	Value  = 0.66 * ((Price - lowest) / (highest - lowest) - 0.5) + 0.67 * Value[1]   (clamped to +/-0.999)
+	Fisher = 0.5 * ln((1 + Value) / (1 - Value)) + 0.5 * Fisher[1]
+	Signal = Fisher[1]
+
+A common period is 10. The inverse Fisher transform, tanh(x), does the opposite:
+it compresses an oscillator into [-1, 1] so that readings near the extremes stand out.
+-----------------------------------------
+*/
+type FisherTransform struct {
+	Period int
+}
+
+// NewFisherTransform returns a FisherTransform with the given lookback.
+func NewFisherTransform(period int) *FisherTransform {
+	return &FisherTransform{Period: period}
+}
+
+// Calculate returns the Fisher line and its signal (trigger) line, each the same length as the inputs.
+// The first (Period-1) Fisher values and the first Period signal values are math.NaN().
+func (f *FisherTransform) Calculate(highs, lows []float64) ([]float64, []float64, error) {
+	n := len(highs)
+	if n != len(lows) {
+		return nil, nil, errors.New("highs and lows must have the same length")
+	}
+	if f.Period < 1 {
+		return nil, nil, errors.New("period must be >= 1 for Fisher Transform")
+	}
+	if n < f.Period {
+		return nil, nil, errors.New("not enough data for Fisher Transform")
+	}
+
+	mid := make([]float64, n)
+	for i := 0; i < n; i++ {
+		mid[i] = (highs[i] + lows[i]) / 2
+	}
+
+	fisher := make([]float64, n)
+	signal := make([]float64, n)
+	for i := 0; i < f.Period-1; i++ {
+		fisher[i] = math.NaN()
+		signal[i] = math.NaN()
+	}
+	var value, prevFisher float64
+	signal[f.Period-1] = math.NaN()
+	for i := f.Period - 1; i < n; i++ {
+		lowV, highV := lowestHighest(mid, mid, i-f.Period+1, i)
+		normalized := 0.0
+		if highV != lowV {
+			normalized = (mid[i]-lowV)/(highV-lowV) - 0.5
+		}
+		value = 0.66*normalized + 0.67*value
+		value = math.Max(-0.999, math.Min(0.999, value))
+
+		fisher[i] = 0.5*math.Log((1+value)/(1-value)) + 0.5*prevFisher
+		if i > f.Period-1 {
+			signal[i] = prevFisher
+		}
+		prevFisher = fisher[i]
+	}
+	return fisher, signal, nil
+}
+
+// InverseFisher applies the inverse Fisher transform tanh(Scale * (x - Offset)).
+// For RSI, NewInverseFisher(50, 0.1) maps 0..100 onto roughly -1..1.
+type InverseFisher struct {
+	Offset float64
+	Scale  float64
+}
+
+// NewInverseFisher returns an InverseFisher with the given centering offset and scale.
+func NewInverseFisher(offset, scale float64) *InverseFisher {
+	return &InverseFisher{Offset: offset, Scale: scale}
+}
+
+// Calculate returns the transformed values in [-1, 1]; NaN inputs stay NaN.
+func (f *InverseFisher) Calculate(values []float64) ([]float64, error) {
+	if len(values) == 0 {
+		return nil, errors.New("no data provided for Inverse Fisher")
+	}
+	out := make([]float64, len(values))
+	for i, v := range values {
+		out[i] = math.Tanh(f.Scale * (v - f.Offset))
+	}
+	return out, nil
+}
@@ -0,0 +1,64 @@
+package indicators
+
+import "errors"
+
+/*
+Williams Fractals:
+-----------------------------------------
+Bill Williams ("Trading Chaos", 1995):
+
+	Up fractal at bar i:    high[i] > the highs of the 2 bars before and the 2 bars after
+	Down fractal at bar i:  low[i]  < the lows of the 2 bars before and the 2 bars after
+
+A fractal needs the bars after it, so it is only known 'Right' bars later. Up/Down
+mark the fractal bar itself (useful for charting and for analysis after the fact);
+UpConfirmed/DownConfirmed mark the bar on which the fractal became known and are
+safe to trade on without look-ahead.
+-----------------------------------------
+*/
+type Fractals struct {
+	Left  int // bars before the fractal bar (Williams uses 2)
+	Right int // bars after the fractal bar (Williams uses 2)
+}
+
+// FractalsResult holds the fractals as bar indices and as per-bar flags.
+type FractalsResult struct {
+	Up            []int  // indices of up fractals (swing highs)
+	Down          []int  // indices of down fractals (swing lows)
+	UpConfirmed   []bool // true at index+Right for each up fractal
+	DownConfirmed []bool // true at index+Right for each down fractal
+}
+
+// NewFractals returns the classic 5-bar Williams fractal.
+func NewFractals() *Fractals {
+	return &Fractals{Left: 2, Right: 2}
+}
+
+// Calculate expects highs and lows of equal length.
+func (f *Fractals) Calculate(highs, lows []float64) (*FractalsResult, error) {
+	if len(highs) != len(lows) {
+		return nil, errors.New("highs and lows must have the same length")
+	}
+	if f.Left < 1 || f.Right < 1 {
+		return nil, errors.New("fractal windows must be >= 1")
+	}
+	n := len(highs)
+	if n < f.Left+f.Right+1 {
+		return nil, errors.New("not enough data for Fractals")
+	}
+
+	up, down := swingPoints(highs, lows, f.Left, f.Right)
+	res := &FractalsResult{
+		Up:            up,
+		Down:          down,
+		UpConfirmed:   make([]bool, n),
+		DownConfirmed: make([]bool, n),
+	}
+	for _, i := range up {
+		res.UpConfirmed[i+f.Right] = true
+	}
+	for _, i := range down {
+		res.DownConfirmed[i+f.Right] = true
+	}
+	return res, nil
+}
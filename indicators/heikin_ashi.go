@@ -0,0 +1,62 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+/*
+Heikin-Ashi Candles:
+-----------------------------------------
+HA Close[i] = (Open[i] + High[i] + Low[i] + Close[i]) / 4
+HA Open[0]  = (Open[0] + Close[0]) / 2
+HA Open[i]  = (HA Open[i-1] + HA Close[i-1]) / 2
+HA High[i]  = max(High[i], HA Open[i], HA Close[i])
+HA Low[i]   = min(Low[i], HA Open[i], HA Close[i])
+
+Heikin-Ashi bars map one-to-one onto the source bars, so bar i of the result
+corresponds to bar i of the input; Time and Volume are copied unchanged.
+-----------------------------------------
+*/
+type HeikinAshi struct{}
+
+func NewHeikinAshi() *HeikinAshi {
+	return &HeikinAshi{}
+}
+
+// Calculate converts bars (Open is required) into a Heikin-Ashi series that any
+// indicator can consume, e.g. supertrend.Calculate(ha.High, ha.Low, ha.Close).
+func (h *HeikinAshi) Calculate(bars *OHLCV) (*OHLCV, error) {
+	if err := bars.Validate(); err != nil {
+		return nil, err
+	}
+	if len(bars.Open) == 0 {
+		return nil, errors.New("open prices are required for Heikin-Ashi")
+	}
+	n := bars.Len()
+
+	out := &OHLCV{
+		Open:  make([]float64, n),
+		High:  make([]float64, n),
+		Low:   make([]float64, n),
+		Close: make([]float64, n),
+	}
+	if len(bars.Time) != 0 {
+		out.Time = append(out.Time, bars.Time...)
+	}
+	if len(bars.Volume) != 0 {
+		out.Volume = append(out.Volume, bars.Volume...)
+	}
+
+	for i := 0; i < n; i++ {
+		out.Close[i] = (bars.Open[i] + bars.High[i] + bars.Low[i] + bars.Close[i]) / 4
+		if i == 0 {
+			out.Open[i] = (bars.Open[0] + bars.Close[0]) / 2
+		} else {
+			out.Open[i] = (out.Open[i-1] + out.Close[i-1]) / 2
+		}
+		out.High[i] = math.Max(bars.High[i], math.Max(out.Open[i], out.Close[i]))
+		out.Low[i] = math.Min(bars.Low[i], math.Min(out.Open[i], out.Close[i]))
+	}
+	return out, nil
+}
@@ -0,0 +1,178 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+/*
+Hilbert Transform Dominant Cycle (John Ehlers):
+-----------------------------------------
+From "Rocket Science for Traders" (2001). Prices are smoothed with a 4-bar WMA,
+detrended, and split into in-phase (I) and quadrature (Q) components with a
+discrete Hilbert transform. The homodyne discriminator then measures the phase
+change per bar, which gives the dominant cycle period:
+
+	Smooth    = (4*P + 3*P[1] + 2*P[2] + P[3]) / 10
+	Detrender = HT(Smooth)
+	I1, Q1    = Detrender[3], HT(Detrender)
+	I2, Q2    = I1 - HT(Q1), Q1 + HT(I1)          (smoothed 0.2/0.8)
+	Re, Im    = I2*I2[1] + Q2*Q2[1], I2*Q2[1] - Q2*I2[1]   (smoothed 0.2/0.8)
+	Period    = 360 / atan(Im/Re), limited to [6, 50] and to +/-50% per bar
+
+where HT(x) = (0.0962*x + 0.5769*x[2] - 0.5769*x[4] - 0.0962*x[6]) * (0.075*Period[1] + 0.54).
+
+The dominant cycle phase correlates the last DCPeriod smoothed prices with a
+sine and cosine of that period.
+
+The first hilbertWarmUp values are math.NaN(): the filters start from zero and
+need roughly that many bars to settle (TA-Lib uses the same lookback).
+-----------------------------------------
+*/
+
+// hilbertWarmUp is the number of leading bars reported as NaN by Hilbert-based indicators.
+const hilbertWarmUp = 32
+
+// HilbertDominantCycle measures the dominant cycle period and phase of a price series.
+type HilbertDominantCycle struct{}
+
+// NewHilbertDominantCycle returns a HilbertDominantCycle instance.
+func NewHilbertDominantCycle() *HilbertDominantCycle {
+	return &HilbertDominantCycle{}
+}
+
+// Calculate returns two slices, each the same length as prices:
+//   - period[i]: smoothed dominant cycle period in bars (6..50)
+//   - phase[i]:  dominant cycle phase in degrees (-45..315)
+func (h *HilbertDominantCycle) Calculate(prices []float64) ([]float64, []float64, error) {
+	if len(prices) <= hilbertWarmUp {
+		return nil, nil, errors.New("not enough data for Hilbert Transform dominant cycle")
+	}
+	ht := hilbertTransform(prices)
+	n := len(prices)
+
+	period := make([]float64, n)
+	phase := make([]float64, n)
+	prevPhase := 0.0
+	for i := 0; i < n; i++ {
+		dcPeriod := int(ht.smoothPeriod[i] + 0.5)
+		if i < hilbertWarmUp || dcPeriod < 1 || i-dcPeriod+1 < 0 {
+			period[i] = math.NaN()
+			phase[i] = math.NaN()
+			continue
+		}
+		period[i] = ht.smoothPeriod[i]
+
+		var realPart, imagPart float64
+		for count := 0; count < dcPeriod; count++ {
+			angle := 2 * math.Pi * float64(count) / float64(dcPeriod)
+			realPart += math.Sin(angle) * ht.smooth[i-count]
+			imagPart += math.Cos(angle) * ht.smooth[i-count]
+		}
+		dcPhase := prevPhase
+		if math.Abs(imagPart) > 0 {
+			dcPhase = math.Atan(realPart/imagPart) * 180 / math.Pi
+		}
+		if math.Abs(imagPart) <= 0.001 {
+			if realPart > 0 {
+				dcPhase += 90
+			} else if realPart < 0 {
+				dcPhase -= 90
+			}
+		}
+		dcPhase += 90
+		// Compensate for the one-bar lag of the smoother.
+		dcPhase += 360 / ht.smoothPeriod[i]
+		if imagPart < 0 {
+			dcPhase += 180
+		}
+		if dcPhase > 315 {
+			dcPhase -= 360
+		}
+		phase[i] = dcPhase
+		prevPhase = dcPhase
+	}
+
+	return period, phase, nil
+}
+
+// hilbertState holds the per-bar intermediate series of the Hilbert transform.
+type hilbertState struct {
+	smooth       []float64
+	i1           []float64
+	q1           []float64
+	period       []float64
+	smoothPeriod []float64
+}
+
+// hilbertTransform runs Ehlers' Hilbert transform / homodyne discriminator over prices.
+func hilbertTransform(prices []float64) *hilbertState {
+	n := len(prices)
+	s := &hilbertState{
+		smooth:       make([]float64, n),
+		i1:           make([]float64, n),
+		q1:           make([]float64, n),
+		period:       make([]float64, n),
+		smoothPeriod: make([]float64, n),
+	}
+	detrender := make([]float64, n)
+	i2 := make([]float64, n)
+	q2 := make([]float64, n)
+	re := make([]float64, n)
+	im := make([]float64, n)
+
+	// at returns x[i-lag], or 0 before the start of the series.
+	at := func(x []float64, i, lag int) float64 {
+		if i-lag < 0 {
+			return 0
+		}
+		return x[i-lag]
+	}
+	transform := func(x []float64, i int, adj float64) float64 {
+		return (0.0962*at(x, i, 0) + 0.5769*at(x, i, 2) - 0.5769*at(x, i, 4) - 0.0962*at(x, i, 6)) * adj
+	}
+
+	for i := 0; i < n; i++ {
+		if i < 3 {
+			s.smooth[i] = prices[i]
+			continue
+		}
+		s.smooth[i] = (4*prices[i] + 3*prices[i-1] + 2*prices[i-2] + prices[i-3]) / 10
+		prevPeriod := at(s.period, i, 1)
+		adj := 0.075*prevPeriod + 0.54
+
+		detrender[i] = transform(s.smooth, i, adj)
+		s.q1[i] = transform(detrender, i, adj)
+		s.i1[i] = at(detrender, i, 3)
+
+		// Advance the phase of I1 and Q1 by 90 degrees.
+		jI := transform(s.i1, i, adj)
+		jQ := transform(s.q1, i, adj)
+		i2[i] = 0.2*(s.i1[i]-jQ) + 0.8*at(i2, i, 1)
+		q2[i] = 0.2*(s.q1[i]+jI) + 0.8*at(q2, i, 1)
+
+		// Homodyne discriminator
+		re[i] = 0.2*(i2[i]*at(i2, i, 1)+q2[i]*at(q2, i, 1)) + 0.8*at(re, i, 1)
+		im[i] = 0.2*(i2[i]*at(q2, i, 1)-q2[i]*at(i2, i, 1)) + 0.8*at(im, i, 1)
+
+		p := prevPeriod
+		if im[i] != 0 && re[i] != 0 {
+			p = 360 / (math.Atan(im[i]/re[i]) * 180 / math.Pi)
+		}
+		if p > 1.5*prevPeriod {
+			p = 1.5 * prevPeriod
+		}
+		if p < 0.67*prevPeriod {
+			p = 0.67 * prevPeriod
+		}
+		if p < 6 {
+			p = 6
+		}
+		if p > 50 {
+			p = 50
+		}
+		s.period[i] = 0.2*p + 0.8*prevPeriod
+		s.smoothPeriod[i] = 0.33*s.period[i] + 0.67*at(s.smoothPeriod, i, 1)
+	}
+	return s
+}
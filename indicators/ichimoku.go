@@ -12,6 +12,9 @@ type Ichimoku struct {
 	KijunPeriod  int
 	SenkouPeriod int
 	Shift        int
+	// ExtendCloud makes Calculate return Senkou Span A/B with Shift extra bars,
+	// holding the cloud projected into the future beyond the last input bar.
+	ExtendCloud bool
 }
 
 // NewIchimoku creates an Ichimoku instance with common default values:
@@ -32,10 +35,17 @@ func NewIchimoku(tenkan, kijun, senkou, shift int) *Ichimoku {
 //  4. Senkou Span B
 //  5. Chikou Span
 //
-// All slices have the same length as input (high, low, close).
+// All slices have the same length as input (high, low, close), except Senkou Span A/B
+// which have len(input)+Shift values when ExtendCloud is set.
 // For days where a value can't be computed, we insert math.NaN().
 func (i *Ichimoku) Calculate(high, low, close []float64) (
 	[]float64, []float64, []float64, []float64, []float64, error,
+) {
+	return i.calculate(high, low, close, i.ExtendCloud)
+}
+
+func (i *Ichimoku) calculate(high, low, close []float64, extend bool) (
+	[]float64, []float64, []float64, []float64, []float64, error,
 ) {
 	n := len(high)
 	if n != len(low) || n != len(close) {
@@ -45,19 +55,26 @@ func (i *Ichimoku) Calculate(high, low, close []float64) (
 		return nil, nil, nil, nil, nil, errors.New("not enough data for Ichimoku: need >= senkouPeriod bars")
 	}
 
+	spanLen := n
+	if extend {
+		spanLen = n + i.Shift
+	}
+
 	tenkanVals := make([]float64, n)
 	kijunVals := make([]float64, n)
-	spanA := make([]float64, n)
-	spanB := make([]float64, n)
+	spanA := make([]float64, spanLen)
+	spanB := make([]float64, spanLen)
 	chikouSpan := make([]float64, n)
 
 	// Initialize all to NaN
 	for idx := 0; idx < n; idx++ {
 		tenkanVals[idx] = math.NaN()
 		kijunVals[idx] = math.NaN()
+		chikouSpan[idx] = math.NaN()
+	}
+	for idx := 0; idx < spanLen; idx++ {
 		spanA[idx] = math.NaN()
 		spanB[idx] = math.NaN()
-		chikouSpan[idx] = math.NaN()
 	}
 
 	// Helper function to get min and max in a window
@@ -98,7 +115,7 @@ func (i *Ichimoku) Calculate(high, low, close []float64) (
 			continue
 		}
 		forwardIndex := idx + i.Shift
-		if forwardIndex < n {
+		if forwardIndex < spanLen {
 			spanA[forwardIndex] = (tenkanVals[idx] + kijunVals[idx]) / 2.0
 		}
 	}
@@ -109,7 +126,7 @@ func (i *Ichimoku) Calculate(high, low, close []float64) (
 		start := idx - (i.SenkouPeriod - 1)
 		lowMin, highMax := getMinMax(start, idx)
 		forwardIndex := idx + i.Shift
-		if forwardIndex < n {
+		if forwardIndex < spanLen {
 			spanB[forwardIndex] = (highMax + lowMin) / 2.0
 		}
 	}
@@ -124,3 +141,74 @@ func (i *Ichimoku) Calculate(high, low, close []float64) (
 
 	return tenkanVals, kijunVals, spanA, spanB, chikouSpan, nil
 }
+
+// IchimokuSignals holds signals derived from the Ichimoku lines.
+//
+// PriceVsCloud and TKCross have the same length as the input. CloudColor and
+// CloudTwist cover the projected cloud too, so they have len(input)+Shift values.
+type IchimokuSignals struct {
+	PriceVsCloud []int  // 1 = close above the cloud, -1 = below, 0 = inside or no cloud yet
+	TKCross      []int  // 1 = Tenkan crosses above Kijun, -1 = crosses below, 0 = no cross
+	CloudColor   []int  // 1 = bullish (Span A > Span B), -1 = bearish, 0 = flat or unavailable
+	CloudTwist   []bool // true where the cloud color flips between bullish and bearish
+}
+
+// Signals computes price-vs-cloud position, Tenkan/Kijun crosses and cloud color/twists.
+func (i *Ichimoku) Signals(high, low, close []float64) (*IchimokuSignals, error) {
+	tenkan, kijun, spanA, spanB, _, err := i.calculate(high, low, close, true)
+	if err != nil {
+		return nil, err
+	}
+	n := len(close)
+
+	priceVsCloud := make([]int, n)
+	tkCross := make([]int, n)
+	for idx := 0; idx < n; idx++ {
+		if !math.IsNaN(spanA[idx]) && !math.IsNaN(spanB[idx]) {
+			top := math.Max(spanA[idx], spanB[idx])
+			bottom := math.Min(spanA[idx], spanB[idx])
+			if close[idx] > top {
+				priceVsCloud[idx] = 1
+			} else if close[idx] < bottom {
+				priceVsCloud[idx] = -1
+			}
+		}
+		if idx == 0 || math.IsNaN(tenkan[idx-1]) || math.IsNaN(kijun[idx-1]) {
+			continue
+		}
+		prevDiff := tenkan[idx-1] - kijun[idx-1]
+		currDiff := tenkan[idx] - kijun[idx]
+		if prevDiff <= 0 && currDiff > 0 {
+			tkCross[idx] = 1
+		} else if prevDiff >= 0 && currDiff < 0 {
+			tkCross[idx] = -1
+		}
+	}
+
+	cloudColor := make([]int, len(spanA))
+	cloudTwist := make([]bool, len(spanA))
+	lastColor := 0
+	for idx := range spanA {
+		if math.IsNaN(spanA[idx]) || math.IsNaN(spanB[idx]) {
+			continue
+		}
+		if spanA[idx] > spanB[idx] {
+			cloudColor[idx] = 1
+		} else if spanA[idx] < spanB[idx] {
+			cloudColor[idx] = -1
+		}
+		if cloudColor[idx] != 0 {
+			if lastColor != 0 && cloudColor[idx] != lastColor {
+				cloudTwist[idx] = true
+			}
+			lastColor = cloudColor[idx]
+		}
+	}
+
+	return &IchimokuSignals{
+		PriceVsCloud: priceVsCloud,
+		TKCross:      tkCross,
+		CloudColor:   cloudColor,
+		CloudTwist:   cloudTwist,
+	}, nil
+}
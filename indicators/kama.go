@@ -124,3 +124,65 @@ func (k *KAMA) Calculate(prices []float64) ([]float64, error) {
 
 	return kama, nil
 }
+
+// CalculateAdaptive is Calculate with a per-bar ER period, e.g. the dominant cycle
+// from HilbertDominantCycle (or a fraction of it). periods[i] is rounded to whole bars;
+// bars whose period is math.NaN() before the first valid one are math.NaN(), and KAMA
+// is seeded with the price on that first bar. With a constant period from the first bar
+// the result equals Calculate. FastPeriod and SlowPeriod are used as in Calculate.
+func (k *KAMA) CalculateAdaptive(prices, periods []float64) ([]float64, error) {
+	n := len(prices)
+	if n == 0 {
+		return nil, errors.New("no price data provided for KAMA")
+	}
+	if len(periods) != n {
+		return nil, errors.New("prices and periods must have the same length")
+	}
+	if k.FastPeriod < 1 || k.SlowPeriod < 1 {
+		return nil, errors.New("fastPeriod and slowPeriod must be >= 1 for KAMA")
+	}
+	fastSC := 2.0 / (float64(k.FastPeriod) + 1.0)
+	slowSC := 2.0 / (float64(k.SlowPeriod) + 1.0)
+
+	kama := make([]float64, n)
+	first := -1
+	for i := 0; i < n; i++ {
+		if first < 0 {
+			if math.IsNaN(periods[i]) {
+				kama[i] = math.NaN()
+				continue
+			}
+			first = i
+			kama[i] = prices[i]
+			continue
+		}
+		prevKama := kama[i-1]
+		if math.IsNaN(periods[i]) {
+			kama[i] = prevKama
+			continue
+		}
+		period := int(periods[i] + 0.5)
+		if period < 2 {
+			return nil, errors.New("adaptive ER periods must be >= 2 for KAMA")
+		}
+		if i-first < period {
+			// Not enough bars since the seed for a full ER window yet.
+			kama[i] = prevKama + fastSC*(prices[i]-prevKama)
+			continue
+		}
+
+		startIndex := i - period + 1
+		change := math.Abs(prices[i] - prices[startIndex])
+		var volatility float64
+		for j := startIndex; j < i; j++ {
+			volatility += math.Abs(prices[j+1] - prices[j])
+		}
+		var er float64
+		if volatility != 0 {
+			er = change / volatility
+		}
+		sc := er*(fastSC-slowSC) + slowSC
+		kama[i] = prevKama + sc*sc*(prices[i]-prevKama)
+	}
+	return kama, nil
+}
@@ -0,0 +1,112 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+// MAType selects the moving average used by indicators that smooth an intermediate series.
+type MAType int
+
+const (
+	// MADefault uses the indicator's conventional smoothing (e.g. Wilder for ATR, SMA for %D).
+	MADefault MAType = iota
+	// MASimple is a simple moving average.
+	MASimple
+	// MAExponential is an EMA with alpha = 2/(period+1), seeded with the SMA of the first period.
+	MAExponential
+	// MAWilder is Wilder's smoothing (RMA) with alpha = 1/period, seeded with the SMA of the first period.
+	MAWilder
+)
+
+// resolve replaces MADefault with the given fallback.
+func (t MAType) resolve(fallback MAType) MAType {
+	if t == MADefault {
+		return fallback
+	}
+	return t
+}
+
+// smooth applies a moving average of type t over 'period' values of data, where the
+// first valid input is at index 'start'. Values before start+period-1 are math.NaN().
+func smooth(data []float64, start, period int, t MAType) ([]float64, error) {
+	if period < 1 {
+		return nil, errors.New("smoothing period must be >= 1")
+	}
+	switch t {
+	case MASimple:
+		return rollingMean(data, start, period), nil
+	case MAExponential, MAWilder:
+		alpha := 1.0 / float64(period)
+		if t == MAExponential {
+			alpha = 2.0 / (float64(period) + 1.0)
+		}
+		out := rollingMean(data, start, period)
+		for i := start + period; i < len(data); i++ {
+			out[i] = out[i-1] + alpha*(data[i]-out[i-1])
+		}
+		return out, nil
+	default:
+		return nil, errors.New("unsupported moving average type")
+	}
+}
+
+// nanToZero replaces NaN warm-up values with 0 for indicators that report 0 before warm-up.
+func nanToZero(values []float64) []float64 {
+	for i, v := range values {
+		if math.IsNaN(v) {
+			values[i] = 0
+		}
+	}
+	return values
+}
+
+// maStream is the incremental counterpart of smooth: feeding it the valid inputs one
+// at a time yields the same values smooth produces, with NaN until 'period' inputs are seen.
+type maStream struct {
+	kind   MAType
+	period int
+	window []float64
+	value  float64
+	ready  bool
+}
+
+func newMAStream(kind MAType, period int) (*maStream, error) {
+	if period < 1 {
+		return nil, errors.New("smoothing period must be >= 1")
+	}
+	switch kind {
+	case MASimple, MAExponential, MAWilder:
+	default:
+		return nil, errors.New("unsupported moving average type")
+	}
+	return &maStream{kind: kind, period: period, window: make([]float64, 0, period)}, nil
+}
+
+// update adds x and returns the current average, or math.NaN() during warm-up.
+func (m *maStream) update(x float64) float64 {
+	if m.ready && m.kind != MASimple {
+		alpha := 1.0 / float64(m.period)
+		if m.kind == MAExponential {
+			alpha = 2.0 / (float64(m.period) + 1.0)
+		}
+		m.value += alpha * (x - m.value)
+		return m.value
+	}
+
+	if len(m.window) == m.period {
+		m.window = append(m.window[:0], m.window[1:]...)
+	}
+	m.window = append(m.window, x)
+	if len(m.window) < m.period {
+		return math.NaN()
+	}
+	// Summed oldest to newest, exactly like rollingMean.
+	var sum float64
+	for _, v := range m.window {
+		sum += v
+	}
+	m.value = sum / float64(m.period)
+	m.ready = true
+	return m.value
+}
@@ -2,6 +2,7 @@ package indicators
 
 import (
 	"errors"
+	"math"
 )
 
 type MACD struct {
@@ -41,3 +42,49 @@ func (m *MACD) Calculate(prices []float64) ([]float64, []float64, []float64, err
 	}
 	return macdLine, signalLine, hist, nil
 }
+
+// MACDStream computes the MACD one price at a time. After warm-up it matches
+// Calculate; the MACD line is math.NaN() for the first SlowPeriod-1 prices and the
+// signal line and histogram for the first SlowPeriod+SignalPeriod-2.
+type MACDStream struct {
+	cfg                   MACD
+	fast, slow, signal    float64
+	kFast, kSlow, kSignal float64
+	count                 int
+}
+
+// NewStream returns a MACDStream with this MACD's periods.
+func (m *MACD) NewStream() (*MACDStream, error) {
+	if m.FastPeriod < 1 || m.SlowPeriod < 1 || m.SignalPeriod < 1 {
+		return nil, errors.New("MACD periods must be >= 1")
+	}
+	return &MACDStream{
+		cfg:     *m,
+		kFast:   2.0 / (float64(m.FastPeriod) + 1.0),
+		kSlow:   2.0 / (float64(m.SlowPeriod) + 1.0),
+		kSignal: 2.0 / (float64(m.SignalPeriod) + 1.0),
+	}, nil
+}
+
+// Update adds a price and returns the MACD line, signal line and histogram.
+func (st *MACDStream) Update(price float64) (float64, float64, float64) {
+	// Same recurrences as EMA.Calculate, each seeded with its first input.
+	if st.count == 0 {
+		st.fast, st.slow = price, price
+		st.signal = 0
+	} else {
+		st.fast = (price * st.kFast) + (st.fast * (1.0 - st.kFast))
+		st.slow = (price * st.kSlow) + (st.slow * (1.0 - st.kSlow))
+		st.signal = ((st.fast - st.slow) * st.kSignal) + (st.signal * (1.0 - st.kSignal))
+	}
+	st.count++
+
+	macd, signal := st.fast-st.slow, st.signal
+	if st.count < st.cfg.SlowPeriod {
+		return math.NaN(), math.NaN(), math.NaN()
+	}
+	if st.count < st.cfg.SlowPeriod+st.cfg.SignalPeriod-1 {
+		return macd, math.NaN(), math.NaN()
+	}
+	return macd, signal, macd - signal
+}
@@ -0,0 +1,77 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+/*
+MESA Adaptive Moving Average (MAMA) and Following Adaptive Moving Average (FAMA):
+-----------------------------------------
+Developed by John Ehlers (2001). The rate of change of the Hilbert transform phase
+drives the smoothing factor, so the average speeds up when the cycle phase moves
+quickly (trends) and slows down in cycling markets:
+
+	Phase      = atan(Q1 / I1)
+	DeltaPhase = max(Phase[1] - Phase, 1)
+	alpha      = max(fastLimit / DeltaPhase, slowLimit)
+	MAMA       = alpha * Price + (1 - alpha) * MAMA[1]
+	FAMA       = 0.5*alpha * MAMA + (1 - 0.5*alpha) * FAMA[1]
+
+Common defaults: fastLimit = 0.5, slowLimit = 0.05.
+-----------------------------------------
+*/
+type MAMA struct {
+	FastLimit float64
+	SlowLimit float64
+}
+
+// NewMAMA returns a MAMA instance with the given fast and slow alpha limits.
+func NewMAMA(fastLimit, slowLimit float64) *MAMA {
+	return &MAMA{FastLimit: fastLimit, SlowLimit: slowLimit}
+}
+
+// Calculate returns the MAMA and FAMA lines, each the same length as prices.
+// The first values are math.NaN() while the Hilbert transform settles.
+func (m *MAMA) Calculate(prices []float64) ([]float64, []float64, error) {
+	if m.SlowLimit <= 0 || m.FastLimit < m.SlowLimit || m.FastLimit > 1 {
+		return nil, nil, errors.New("MAMA limits must satisfy 0 < slowLimit <= fastLimit <= 1")
+	}
+	if len(prices) <= hilbertWarmUp {
+		return nil, nil, errors.New("not enough data for MAMA")
+	}
+	ht := hilbertTransform(prices)
+	n := len(prices)
+
+	mama := make([]float64, n)
+	fama := make([]float64, n)
+	mamaPrev, famaPrev := prices[0], prices[0]
+	prevPhase := 0.0
+	for i := 0; i < n; i++ {
+		phase := prevPhase
+		if ht.i1[i] != 0 {
+			phase = math.Atan(ht.q1[i]/ht.i1[i]) * 180 / math.Pi
+		}
+		deltaPhase := prevPhase - phase
+		if deltaPhase < 1 {
+			deltaPhase = 1
+		}
+		prevPhase = phase
+
+		alpha := m.FastLimit / deltaPhase
+		if alpha < m.SlowLimit {
+			alpha = m.SlowLimit
+		}
+		mamaPrev = alpha*prices[i] + (1-alpha)*mamaPrev
+		famaPrev = 0.5*alpha*mamaPrev + (1-0.5*alpha)*famaPrev
+
+		if i < hilbertWarmUp {
+			mama[i] = math.NaN()
+			fama[i] = math.NaN()
+		} else {
+			mama[i] = mamaPrev
+			fama[i] = famaPrev
+		}
+	}
+	return mama, fama, nil
+}
@@ -0,0 +1,80 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+/*
+Mass Index:
+-----------------------------------------
+Developed by Donald Dorsey (1992). Detects range expansion that often precedes reversals:
+
+	Single = EMA(High - Low, emaPeriod)
+	Double = EMA(Single, emaPeriod)
+	Mass   = sum(Single / Double, sumPeriod)
+
+Common defaults: emaPeriod=9, sumPeriod=25. A "reversal bulge" occurs when the
+index rises above 27 and then falls back below 26.5.
+-----------------------------------------
+*/
+type MassIndex struct {
+	EMAPeriod int
+	SumPeriod int
+}
+
+// NewMassIndex returns a MassIndex instance with the given EMA and summation periods.
+func NewMassIndex(emaPeriod, sumPeriod int) *MassIndex {
+	return &MassIndex{EMAPeriod: emaPeriod, SumPeriod: sumPeriod}
+}
+
+// Calculate returns a slice of Mass Index values, the same length as the inputs.
+// The first (SumPeriod-1) values are math.NaN().
+func (m *MassIndex) Calculate(highs, lows []float64) ([]float64, error) {
+	n := len(highs)
+	if n != len(lows) {
+		return nil, errors.New("highs and lows must have the same length")
+	}
+	if m.EMAPeriod < 1 || m.SumPeriod < 1 {
+		return nil, errors.New("periods must be >= 1 for Mass Index")
+	}
+	if n < m.SumPeriod || n < m.EMAPeriod {
+		return nil, errors.New("not enough data for Mass Index")
+	}
+
+	ranges := make([]float64, n)
+	for i := 0; i < n; i++ {
+		ranges[i] = highs[i] - lows[i]
+	}
+	single, err := NewEMA(m.EMAPeriod).Calculate(ranges)
+	if err != nil {
+		return nil, err
+	}
+	double, err := NewEMA(m.EMAPeriod).Calculate(single)
+	if err != nil {
+		return nil, err
+	}
+
+	ratio := make([]float64, n)
+	for i := 0; i < n; i++ {
+		if double[i] == 0 {
+			ratio[i] = 1
+		} else {
+			ratio[i] = single[i] / double[i]
+		}
+	}
+
+	mass := make([]float64, n)
+	for i := 0; i < m.SumPeriod-1; i++ {
+		mass[i] = math.NaN()
+	}
+	for i := m.SumPeriod - 1; i < n; i++ {
+		var sum float64
+		for j := i - m.SumPeriod + 1; j <= i; j++ {
+			sum += ratio[j]
+		}
+		mass[i] = sum
+	}
+
+	return mass, nil
+}
@@ -0,0 +1,40 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+// Momentum measures the absolute price change over 'Window' bars:
+//
+//	Momentum[i] = Price[i] - Price[i-Window]
+//
+// It is the price-unit counterpart of ROC.
+type Momentum struct {
+	Window int
+}
+
+// NewMomentum returns a Momentum instance with the given lookback (often 10).
+func NewMomentum(window int) *Momentum {
+	return &Momentum{Window: window}
+}
+
+// Calculate returns a slice of momentum values, the same length as prices.
+// The first 'Window' values are math.NaN().
+func (m *Momentum) Calculate(prices []float64) ([]float64, error) {
+	if m.Window < 1 {
+		return nil, errors.New("window must be >= 1 for Momentum")
+	}
+	if len(prices) <= m.Window {
+		return nil, errors.New("not enough data for Momentum")
+	}
+
+	out := make([]float64, len(prices))
+	for i := 0; i < m.Window; i++ {
+		out[i] = math.NaN()
+	}
+	for i := m.Window; i < len(prices); i++ {
+		out[i] = prices[i] - prices[i-m.Window]
+	}
+	return out, nil
+}
@@ -0,0 +1,160 @@
+package indicators
+
+import (
+	"errors"
+	"time"
+)
+
+// OHLCV is a bar series stored as parallel slices, so each field can be passed
+// straight to the indicator Calculate methods, e.g.
+//
+//	atr.Calculate(bars.High, bars.Low, bars.Close)
+//
+// High, Low and Close are required. Time, Open and Volume are optional and may be
+// left empty when an indicator doesn't need them.
+type OHLCV struct {
+	Time   []time.Time
+	Open   []float64
+	High   []float64
+	Low    []float64
+	Close  []float64
+	Volume []float64
+}
+
+// Len returns the number of bars in the series.
+func (s *OHLCV) Len() int {
+	return len(s.Close)
+}
+
+// Validate checks that every populated field has the same number of bars.
+func (s *OHLCV) Validate() error {
+	n := len(s.Close)
+	if n == 0 {
+		return errors.New("empty OHLCV series")
+	}
+	if len(s.High) != n || len(s.Low) != n {
+		return errors.New("high, low, and close must have the same length")
+	}
+	if len(s.Open) != 0 && len(s.Open) != n {
+		return errors.New("open must be empty or have the same length as close")
+	}
+	if len(s.Volume) != 0 && len(s.Volume) != n {
+		return errors.New("volume must be empty or have the same length as close")
+	}
+	if len(s.Time) != 0 && len(s.Time) != n {
+		return errors.New("time must be empty or have the same length as close")
+	}
+	return nil
+}
+
+// PriceSource selects which price an indicator is computed on.
+type PriceSource int
+
+const (
+	// SourceHL2 is (high + low) / 2, the bar midpoint.
+	SourceHL2 PriceSource = iota
+	// SourceHLC3 is (high + low + close) / 3, the typical price.
+	SourceHLC3
+	// SourceOHLC4 is (open + high + low + close) / 4.
+	SourceOHLC4
+	// SourceClose is the closing price.
+	SourceClose
+)
+
+// Values derives the selected price series from the bar components, which must have
+// equal lengths. open is only required for SourceOHLC4 and may be nil otherwise.
+func (src PriceSource) Values(open, high, low, close []float64) ([]float64, error) {
+	n := len(close)
+	if len(high) != n || len(low) != n {
+		return nil, errors.New("high, low, and close must have the same length")
+	}
+	out := make([]float64, n)
+	switch src {
+	case SourceHL2:
+		for i := range out {
+			out[i] = (high[i] + low[i]) / 2.0
+		}
+	case SourceHLC3:
+		for i := range out {
+			out[i] = (high[i] + low[i] + close[i]) / 3.0
+		}
+	case SourceOHLC4:
+		if len(open) != n {
+			return nil, errors.New("open prices are required for the ohlc4 source")
+		}
+		for i := range out {
+			out[i] = (open[i] + high[i] + low[i] + close[i]) / 4.0
+		}
+	case SourceClose:
+		copy(out, close)
+	default:
+		return nil, errors.New("unknown price source")
+	}
+	return out, nil
+}
+
+// AlignByTime keeps only the bars whose timestamps appear in both series, so two
+// instruments can be fed to the cross-asset indicators (RollingCorrelation, Beta,
+// PairSpread) bar for bar. Both series must have Time populated and sorted ascending.
+// The inputs are not modified; the returned series hold copies of the matching bars.
+func AlignByTime(a, b *OHLCV) (*OHLCV, *OHLCV, error) {
+	if err := a.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if err := b.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if len(a.Time) == 0 || len(b.Time) == 0 {
+		return nil, nil, errors.New("both series need timestamps to be aligned")
+	}
+
+	var ia, ib []int
+	i, j := 0, 0
+	for i < len(a.Time) && j < len(b.Time) {
+		switch {
+		case i > 0 && a.Time[i].Before(a.Time[i-1]), j > 0 && b.Time[j].Before(b.Time[j-1]):
+			return nil, nil, errors.New("timestamps must be sorted in ascending order")
+		case a.Time[i].Equal(b.Time[j]):
+			ia = append(ia, i)
+			ib = append(ib, j)
+			i++
+			j++
+		case a.Time[i].Before(b.Time[j]):
+			i++
+		default:
+			j++
+		}
+	}
+	if len(ia) == 0 {
+		return nil, nil, errors.New("series have no timestamps in common")
+	}
+	return a.subset(ia), b.subset(ib), nil
+}
+
+// subset returns a new series holding the bars at the given indices.
+func (s *OHLCV) subset(idx []int) *OHLCV {
+	pick := func(src []float64) []float64 {
+		if len(src) == 0 {
+			return nil
+		}
+		out := make([]float64, len(idx))
+		for k, i := range idx {
+			out[k] = src[i]
+		}
+		return out
+	}
+	out := &OHLCV{
+		Open:   pick(s.Open),
+		High:   pick(s.High),
+		Low:    pick(s.Low),
+		Close:  pick(s.Close),
+		Volume: pick(s.Volume),
+	}
+	if len(s.Time) != 0 {
+		out.Time = make([]time.Time, len(idx))
+		for k, i := range idx {
+			out.Time[k] = s.Time[i]
+		}
+	}
+	return out
+}
@@ -0,0 +1,110 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/stat"
+)
+
+/*
+Pair Spread (rolling hedge ratio and z-score):
+-----------------------------------------
+For a pair (y, x), an ordinary least squares fit over the last 'window' bars gives
+
+	y = Intercept + HedgeRatio * x
+
+The spread is the position "long 1 unit of y, short HedgeRatio units of x":
+
+	Spread[i] = y[i] - HedgeRatio[i] * x[i]
+	ZScore[i] = (Spread[i] - mean(Spread)) / stddev(Spread)   over the last 'zWindow' spreads
+
+For a cointegrated pair the spread is mean-reverting; typical rules enter at
+|ZScore| > 2 and exit near 0.
+-----------------------------------------
+*/
+type PairSpread struct {
+	Window  int // bars used for the hedge ratio regression
+	ZWindow int // spreads used for the z-score; 0 means the same as Window
+}
+
+// PairSpreadResult holds the rolling regression and spread series.
+// All slices have the same length as the input; bars that can't be computed yet are math.NaN().
+type PairSpreadResult struct {
+	HedgeRatio []float64
+	Intercept  []float64
+	Spread     []float64
+	ZScore     []float64
+}
+
+// NewPairSpread returns a PairSpread with the given regression and z-score windows.
+func NewPairSpread(window, zWindow int) *PairSpread {
+	return &PairSpread{Window: window, ZWindow: zWindow}
+}
+
+// Calculate expects y (the dependent leg) and x (the hedge leg) of equal length,
+// e.g. closes aligned with AlignByTime. The hedge ratio starts at index Window-1 and
+// the z-score at Window+ZWindow-2.
+func (p *PairSpread) Calculate(y, x []float64) (*PairSpreadResult, error) {
+	if len(x) != len(y) {
+		return nil, errors.New("y and x must have the same length")
+	}
+	zWindow := p.ZWindow
+	if zWindow == 0 {
+		zWindow = p.Window
+	}
+	if p.Window < 2 || zWindow < 2 {
+		return nil, errors.New("windows must be >= 2 for PairSpread")
+	}
+	n := len(y)
+	if n < p.Window {
+		return nil, errors.New("not enough data for PairSpread")
+	}
+
+	res := &PairSpreadResult{
+		HedgeRatio: make([]float64, n),
+		Intercept:  make([]float64, n),
+		Spread:     make([]float64, n),
+		ZScore:     make([]float64, n),
+	}
+	for i := 0; i < n; i++ {
+		res.HedgeRatio[i] = math.NaN()
+		res.Intercept[i] = math.NaN()
+		res.Spread[i] = math.NaN()
+		res.ZScore[i] = math.NaN()
+	}
+
+	for i := p.Window - 1; i < n; i++ {
+		xs := x[i-p.Window+1 : i+1]
+		ys := y[i-p.Window+1 : i+1]
+		if stat.Variance(xs, nil) == 0 {
+			continue
+		}
+		alpha, beta := stat.LinearRegression(xs, ys, nil, false)
+		res.HedgeRatio[i] = beta
+		res.Intercept[i] = alpha
+		res.Spread[i] = y[i] - beta*x[i]
+	}
+
+	for i := p.Window + zWindow - 2; i < n; i++ {
+		window := res.Spread[i-zWindow+1 : i+1]
+		if hasNaN(window) {
+			continue
+		}
+		mean, std := stat.MeanStdDev(window, nil)
+		if std == 0 {
+			continue
+		}
+		res.ZScore[i] = (res.Spread[i] - mean) / std
+	}
+	return res, nil
+}
+
+func hasNaN(data []float64) bool {
+	for _, v := range data {
+		if math.IsNaN(v) {
+			return true
+		}
+	}
+	return false
+}
@@ -13,6 +13,20 @@ type ParabolicSAR struct {
 	IncrementAF float64
 	// MaxAF is the maximum acceleration factor, e.g. 0.20.
 	MaxAF float64
+	// InitialTrend sets the trend of the first bar: 1 = uptrend, -1 = downtrend.
+	// Zero auto-detects it by comparing the midpoints of the first two bars.
+	InitialTrend int
+}
+
+// ParabolicSARResult holds the per-bar state of the Parabolic SAR.
+// EP and AF are the values in effect after bar i has been processed,
+// i.e. the ones used to project the SAR of bar i+1.
+type ParabolicSARResult struct {
+	SAR       []float64
+	Direction []int     // 1 = uptrend (SAR below price), -1 = downtrend (SAR above price)
+	EP        []float64 // extreme point of the current trend
+	AF        []float64 // acceleration factor
+	Reversal  []bool    // true on bars where the trend flipped
 }
 
 // NewParabolicSAR creates a ParabolicSAR struct with given parameters.
@@ -28,6 +42,16 @@ func NewParabolicSAR(startAF, incrementAF, maxAF float64) *ParabolicSAR {
 // It expects high and low slices of equal length.
 // The returned slice has the same length; early bars may be less accurate.
 func (p *ParabolicSAR) Calculate(high, low []float64) ([]float64, error) {
+	res, err := p.CalculateState(high, low)
+	if err != nil {
+		return nil, err
+	}
+	return res.SAR, nil
+}
+
+// CalculateState computes the Parabolic SAR along with the trend direction,
+// extreme point, acceleration factor and reversal flag for each bar.
+func (p *ParabolicSAR) CalculateState(high, low []float64) (*ParabolicSARResult, error) {
 	length := len(high)
 	if length != len(low) {
 		return nil, errors.New("high and low must have the same length")
@@ -35,21 +59,26 @@ func (p *ParabolicSAR) Calculate(high, low []float64) ([]float64, error) {
 	if length == 0 {
 		return nil, errors.New("no data provided")
 	}
+	if p.InitialTrend < -1 || p.InitialTrend > 1 {
+		return nil, errors.New("initial trend must be 1, -1, or 0 (auto-detect)")
+	}
 
 	sar := make([]float64, length)
+	direction := make([]int, length)
+	eps := make([]float64, length)
+	afs := make([]float64, length)
+	reversal := make([]bool, length)
 
-	// 1) Determine initial trend by comparing first two bars
-	//    If second bar's low is higher than first bar's low => likely uptrend, etc.
-	//    Or you can manually set an initial direction. We'll do an auto-detect:
-	upTrend := false
-	if length == 1 {
-		// With only one bar, can't detect direction; just set SAR to that bar's low or high
-		sar[0] = low[0]
-		return sar, nil
-	}
-
-	if (high[1]+low[1])/2 > (high[0]+low[0])/2 {
-		upTrend = true
+	// 1) Determine initial trend: either configured, or by comparing first two bars
+	//    If second bar's midpoint is higher than the first bar's => likely uptrend.
+	upTrend := p.InitialTrend == 1
+	if p.InitialTrend == 0 {
+		if length == 1 {
+			// With only one bar, can't detect direction; assume an uptrend
+			upTrend = true
+		} else if (high[1]+low[1])/2 > (high[0]+low[0])/2 {
+			upTrend = true
+		}
 	}
 
 	// 2) Initialize EP (extreme point) and SAR
@@ -59,10 +88,14 @@ func (p *ParabolicSAR) Calculate(high, low []float64) ([]float64, error) {
 	if upTrend {
 		sar[0] = low[0] // starting SAR below the first bar if uptrend
 		ep = high[0]    // extreme point is the highest high
+		direction[0] = 1
 	} else {
 		sar[0] = high[0] // starting SAR above the first bar if downtrend
 		ep = low[0]      // extreme point is the lowest low
+		direction[0] = -1
 	}
+	eps[0] = ep
+	afs[0] = af
 
 	// 3) Iterate through each bar to compute SAR
 	for i := 1; i < length; i++ {
@@ -81,6 +114,7 @@ func (p *ParabolicSAR) Calculate(high, low []float64) ([]float64, error) {
 				sar[i] = currSAR
 				af = p.StartAF
 				ep = low[i] // new extreme point is current bar's low
+				reversal[i] = true
 			} else {
 				// Stay in uptrend
 				sar[i] = currSAR
@@ -103,6 +137,7 @@ func (p *ParabolicSAR) Calculate(high, low []float64) ([]float64, error) {
 				sar[i] = currSAR
 				af = p.StartAF
 				ep = high[i] // new extreme point is current bar's high
+				reversal[i] = true
 			} else {
 				// Stay in downtrend
 				sar[i] = currSAR
@@ -116,7 +151,106 @@ func (p *ParabolicSAR) Calculate(high, low []float64) ([]float64, error) {
 				}
 			}
 		}
+
+		if upTrend {
+			direction[i] = 1
+		} else {
+			direction[i] = -1
+		}
+		eps[i] = ep
+		afs[i] = af
 	}
 
-	return sar, nil
+	return &ParabolicSARResult{
+		SAR:       sar,
+		Direction: direction,
+		EP:        eps,
+		AF:        afs,
+		Reversal:  reversal,
+	}, nil
+}
+
+// ParabolicSARStream computes the Parabolic SAR one bar at a time and matches
+// CalculateState. When InitialTrend is 0 the first bar's direction depends on the
+// second bar, so the first Update returns math.NaN() and direction 0, and the SAR
+// starts with the second bar.
+type ParabolicSARStream struct {
+	cfg      ParabolicSAR
+	count    int
+	first    [2]float64 // high and low of the first bar, held until the trend is known
+	prevHigh float64
+	prevLow  float64
+	sar      float64
+	ep       float64
+	af       float64
+	upTrend  bool
+}
+
+// NewStream returns a ParabolicSARStream with this configuration.
+func (p *ParabolicSAR) NewStream() (*ParabolicSARStream, error) {
+	if p.InitialTrend < -1 || p.InitialTrend > 1 {
+		return nil, errors.New("initial trend must be 1, -1, or 0 (auto-detect)")
+	}
+	return &ParabolicSARStream{cfg: *p}, nil
+}
+
+// Update adds a bar and returns the SAR, the direction (1 = uptrend, -1 = downtrend)
+// and whether the trend reversed on this bar.
+func (st *ParabolicSARStream) Update(high, low float64) (sar float64, direction int, reversal bool) {
+	st.count++
+	switch {
+	case st.count == 1 && st.cfg.InitialTrend == 0:
+		st.first = [2]float64{high, low}
+		return math.NaN(), 0, false
+	case st.count == 1:
+		st.start(st.cfg.InitialTrend == 1, high, low)
+		return st.sar, st.cfg.InitialTrend, false
+	case st.count == 2 && st.cfg.InitialTrend == 0:
+		h0, l0 := st.first[0], st.first[1]
+		st.start((high+low)/2 > (h0+l0)/2, h0, l0)
+	}
+	reversal = st.step(high, low)
+	if st.upTrend {
+		return st.sar, 1, reversal
+	}
+	return st.sar, -1, reversal
+}
+
+// start initializes the state from the first bar.
+func (st *ParabolicSARStream) start(upTrend bool, high, low float64) {
+	st.upTrend, st.af = upTrend, st.cfg.StartAF
+	if upTrend {
+		st.sar, st.ep = low, high
+	} else {
+		st.sar, st.ep = high, low
+	}
+	st.prevHigh, st.prevLow = high, low
+}
+
+// step advances the SAR by one bar exactly as CalculateState does.
+func (st *ParabolicSARStream) step(high, low float64) bool {
+	reversal := false
+	currSAR := st.sar + st.af*(st.ep-st.sar)
+	if st.upTrend {
+		if currSAR > math.Min(st.prevLow, low) {
+			st.upTrend, reversal = false, true
+			currSAR = math.Max(st.prevHigh, high)
+			st.af, st.ep = st.cfg.StartAF, low
+		} else if high > st.ep {
+			st.ep = high
+			st.af = math.Min(st.af+st.cfg.IncrementAF, st.cfg.MaxAF)
+		}
+	} else {
+		if currSAR < math.Max(st.prevHigh, high) {
+			st.upTrend, reversal = true, true
+			currSAR = math.Min(st.prevLow, low)
+			st.af, st.ep = st.cfg.StartAF, high
+		} else if low < st.ep {
+			st.ep = low
+			st.af = math.Min(st.af+st.cfg.IncrementAF, st.cfg.MaxAF)
+		}
+	}
+	st.sar = currSAR
+	st.prevHigh, st.prevLow = high, low
+	return reversal
 }
@@ -0,0 +1,201 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+/*
+Pivot Points:
+-----------------------------------------
+Levels for the current session are computed from the previous session's
+open (O), high (H), low (L) and close (C), with range R = H - L.
+
+Classic:    P = (H + L + C) / 3
+            R1 = 2P - L,  S1 = 2P - H
+            R2 = P + R,   S2 = P - R
+            R3 = H + 2(P - L),  S3 = L - 2(H - P)
+Fibonacci:  P = (H + L + C) / 3
+            R1/S1 = P +/- 0.382 R,  R2/S2 = P +/- 0.618 R,  R3/S3 = P +/- R
+Woodie:     P = (H + L + 2C) / 4, then R1..R3 / S1..S3 as Classic
+Camarilla:  P = (H + L + C) / 3
+            R1..R4 = C + R * 1.1 / {12, 6, 4, 2},  S1..S4 = C - R * 1.1 / {12, 6, 4, 2}
+DeMark:     X = H + 2L + C if C < O,  2H + L + C if C > O,  H + L + 2C otherwise
+            P = X / 4,  R1 = X / 2 - L,  S1 = X / 2 - H
+
+Levels a method doesn't define (e.g. R4 for Classic) are math.NaN().
+
+Sessions are calendar days in Location, shifted by SessionOffset so sessions that
+don't start at midnight (e.g. futures opening at 18:00 the evening before) group
+correctly: a bar belongs to the day of (time - SessionOffset).
+-----------------------------------------
+*/
+
+// PivotType selects the pivot point formula.
+type PivotType int
+
+const (
+	PivotClassic PivotType = iota
+	PivotFibonacci
+	PivotWoodie
+	PivotCamarilla
+	PivotDeMark
+)
+
+// PivotLevels is one set of pivot levels.
+type PivotLevels struct {
+	P              float64
+	R1, R2, R3, R4 float64
+	S1, S2, S3, S4 float64
+}
+
+// Levels computes the pivot levels from one session's open, high, low and close.
+// open is only used by DeMark pivots.
+func (t PivotType) Levels(open, high, low, close float64) (PivotLevels, error) {
+	nan := math.NaN()
+	lv := PivotLevels{R4: nan, S4: nan}
+	r := high - low
+
+	switch t {
+	case PivotClassic, PivotWoodie:
+		if t == PivotClassic {
+			lv.P = (high + low + close) / 3
+		} else {
+			lv.P = (high + low + 2*close) / 4
+		}
+		lv.R1 = 2*lv.P - low
+		lv.S1 = 2*lv.P - high
+		lv.R2 = lv.P + r
+		lv.S2 = lv.P - r
+		lv.R3 = high + 2*(lv.P-low)
+		lv.S3 = low - 2*(high-lv.P)
+	case PivotFibonacci:
+		lv.P = (high + low + close) / 3
+		lv.R1, lv.S1 = lv.P+0.382*r, lv.P-0.382*r
+		lv.R2, lv.S2 = lv.P+0.618*r, lv.P-0.618*r
+		lv.R3, lv.S3 = lv.P+r, lv.P-r
+	case PivotCamarilla:
+		lv.P = (high + low + close) / 3
+		lv.R1, lv.S1 = close+r*1.1/12, close-r*1.1/12
+		lv.R2, lv.S2 = close+r*1.1/6, close-r*1.1/6
+		lv.R3, lv.S3 = close+r*1.1/4, close-r*1.1/4
+		lv.R4, lv.S4 = close+r*1.1/2, close-r*1.1/2
+	case PivotDeMark:
+		var x float64
+		switch {
+		case close < open:
+			x = high + 2*low + close
+		case close > open:
+			x = 2*high + low + close
+		default:
+			x = high + low + 2*close
+		}
+		lv.P = x / 4
+		lv.R1 = x/2 - low
+		lv.S1 = x/2 - high
+		lv.R2, lv.R3, lv.S2, lv.S3 = nan, nan, nan, nan
+	default:
+		return PivotLevels{}, errors.New("unknown pivot type")
+	}
+	return lv, nil
+}
+
+// PivotPoints computes per-bar pivot levels from the previous session.
+type PivotPoints struct {
+	Type          PivotType
+	Location      *time.Location // time zone that defines session days; nil means UTC
+	SessionOffset time.Duration  // session start relative to midnight, e.g. -6h for an 18:00 open
+}
+
+// PivotPointsResult holds the pivot levels in force at each bar. Bars in the first
+// session have no prior session and are math.NaN().
+type PivotPointsResult struct {
+	P              []float64
+	R1, R2, R3, R4 []float64
+	S1, S2, S3, S4 []float64
+	Session        []int // 0-based session number of each bar
+}
+
+// NewPivotPoints returns PivotPoints of the given type using UTC calendar days.
+func NewPivotPoints(t PivotType) *PivotPoints {
+	return &PivotPoints{Type: t}
+}
+
+// Calculate groups bars into sessions by timestamp and returns, for every bar, the
+// levels computed from the previous session. bars.Time must be populated and sorted;
+// Open is required for DeMark pivots and otherwise optional.
+func (p *PivotPoints) Calculate(bars *OHLCV) (*PivotPointsResult, error) {
+	if err := bars.Validate(); err != nil {
+		return nil, err
+	}
+	if len(bars.Time) == 0 {
+		return nil, errors.New("timestamps are required to group sessions")
+	}
+	if p.Type == PivotDeMark && len(bars.Open) == 0 {
+		return nil, errors.New("open prices are required for DeMark pivots")
+	}
+	if _, err := p.Type.Levels(0, 0, 0, 0); err != nil {
+		return nil, err
+	}
+	loc := p.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	n := bars.Len()
+	res := &PivotPointsResult{Session: make([]int, n)}
+	series := []*[]float64{&res.P, &res.R1, &res.R2, &res.R3, &res.R4, &res.S1, &res.S2, &res.S3, &res.S4}
+	for _, s := range series {
+		*s = make([]float64, n)
+	}
+
+	sessionDay := func(t time.Time) (int, time.Month, int) {
+		return t.In(loc).Add(-p.SessionOffset).Date()
+	}
+
+	// OHLC of the session in progress, and the levels derived from the last completed one
+	var curOpen, curHigh, curLow float64
+	var levels PivotLevels
+	session := 0
+	nan := math.NaN()
+
+	y0, m0, d0 := sessionDay(bars.Time[0])
+	for i := 0; i < n; i++ {
+		if i > 0 && bars.Time[i].Before(bars.Time[i-1]) {
+			return nil, errors.New("timestamps must be sorted in ascending order")
+		}
+		open := bars.Close[i]
+		if len(bars.Open) != 0 {
+			open = bars.Open[i]
+		}
+		y, m, d := sessionDay(bars.Time[i])
+		switch {
+		case i == 0:
+			curOpen, curHigh, curLow = open, bars.High[i], bars.Low[i]
+		case y != y0 || m != m0 || d != d0:
+			lv, err := p.Type.Levels(curOpen, curHigh, curLow, bars.Close[i-1])
+			if err != nil {
+				return nil, err
+			}
+			levels = lv
+			session++
+			y0, m0, d0 = y, m, d
+			curOpen, curHigh, curLow = open, bars.High[i], bars.Low[i]
+		default:
+			curHigh = math.Max(curHigh, bars.High[i])
+			curLow = math.Min(curLow, bars.Low[i])
+		}
+
+		res.Session[i] = session
+		if session == 0 {
+			for _, s := range series {
+				(*s)[i] = nan
+			}
+			continue
+		}
+		res.P[i], res.R1[i], res.R2[i], res.R3[i], res.R4[i] = levels.P, levels.R1, levels.R2, levels.R3, levels.R4
+		res.S1[i], res.S2[i], res.S3[i], res.S4[i] = levels.S1, levels.S2, levels.S3, levels.S4
+	}
+	return res, nil
+}
@@ -0,0 +1,127 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+/*
+Point-and-Figure Columns:
+-----------------------------------------
+Closing prices are quantized into boxes of 'boxSize'. A column of X's rises while
+the close makes new box highs; a column of O's falls while it makes new box lows.
+A new column only starts when price reverses by 'reversal' boxes (3 is the classic
+setting), beginning one box away from the end of the previous column.
+
+Each column is returned as a bar: Open is the first box, Close the last box,
+High/Low the column's range. It is stamped with the time of the last source bar
+that extended it, and Volume (when present) is the total traded while the column
+was current. The last column is still open and can keep growing.
+-----------------------------------------
+*/
+type PointAndFigure struct {
+	BoxSize  float64
+	Reversal int
+}
+
+// PointAndFigureResult holds the columns and how they map back to the source bars.
+type PointAndFigureResult struct {
+	Bars        *OHLCV
+	Direction   []int // 1 for X columns, -1 for O columns
+	Boxes       []int // number of boxes in each column
+	StartIndex  []int // index of the source bar that started each column
+	SourceIndex []int // index of the last source bar that extended each column
+}
+
+// NewPointAndFigure returns a Point-and-Figure transform, e.g. NewPointAndFigure(1, 3).
+func NewPointAndFigure(boxSize float64, reversal int) *PointAndFigure {
+	return &PointAndFigure{BoxSize: boxSize, Reversal: reversal}
+}
+
+// Calculate converts bars into Point-and-Figure columns. The result may be empty
+// if prices never move a full box from the first close.
+func (p *PointAndFigure) Calculate(bars *OHLCV) (*PointAndFigureResult, error) {
+	if err := bars.Validate(); err != nil {
+		return nil, err
+	}
+	if p.BoxSize <= 0 || p.Reversal < 1 {
+		return nil, errors.New("box size must be > 0 and reversal >= 1 for Point-and-Figure")
+	}
+	box := p.BoxSize
+	rev := float64(p.Reversal) * box
+	// tolerance keeps prices that sit exactly on a box boundary from being
+	// pushed into the neighbouring box by floating point error
+	floorBox := func(x float64) float64 { return math.Floor(x/box+1e-9) * box }
+	ceilBox := func(x float64) float64 { return math.Ceil(x/box-1e-9) * box }
+
+	type column struct {
+		dir         int
+		top, bottom float64
+		start, last int
+		volume      float64
+	}
+	var cols []column
+	hasVolume := len(bars.Volume) != 0
+	var pending float64 // volume traded before the first column
+
+	ref := bars.Close[0]
+	for i := 0; i < bars.Len(); i++ {
+		c := bars.Close[i]
+		var vol float64
+		if hasVolume {
+			vol = bars.Volume[i]
+		}
+		if len(cols) == 0 {
+			pending += vol
+			switch {
+			case floorBox(c) >= floorBox(ref)+box:
+				cols = append(cols, column{dir: 1, bottom: floorBox(ref), top: floorBox(c), start: i, last: i, volume: pending})
+			case ceilBox(c) <= ceilBox(ref)-box:
+				cols = append(cols, column{dir: -1, top: ceilBox(ref), bottom: ceilBox(c), start: i, last: i, volume: pending})
+			}
+			continue
+		}
+
+		cur := &cols[len(cols)-1]
+		if cur.dir == 1 {
+			if floorBox(c) > cur.top {
+				cur.top = floorBox(c)
+				cur.last = i
+			} else if ceilBox(c) <= cur.top-rev {
+				cols = append(cols, column{dir: -1, top: cur.top - box, bottom: ceilBox(c), start: i, last: i})
+			}
+		} else {
+			if ceilBox(c) < cur.bottom {
+				cur.bottom = ceilBox(c)
+				cur.last = i
+			} else if floorBox(c) >= cur.bottom+rev {
+				cols = append(cols, column{dir: 1, bottom: cur.bottom + box, top: floorBox(c), start: i, last: i})
+			}
+		}
+		cols[len(cols)-1].volume += vol
+	}
+
+	res := &PointAndFigureResult{Bars: &OHLCV{}}
+	b := res.Bars
+	for _, col := range cols {
+		open, close := col.bottom, col.top
+		if col.dir == -1 {
+			open, close = col.top, col.bottom
+		}
+		b.Open = append(b.Open, open)
+		b.Close = append(b.Close, close)
+		b.High = append(b.High, col.top)
+		b.Low = append(b.Low, col.bottom)
+		if len(bars.Time) != 0 {
+			b.Time = append(b.Time, bars.Time[col.last])
+		}
+		if hasVolume {
+			b.Volume = append(b.Volume, col.volume)
+		}
+		res.Direction = append(res.Direction, col.dir)
+		res.Boxes = append(res.Boxes, int(math.Round((col.top-col.bottom)/box))+1)
+		res.StartIndex = append(res.StartIndex, col.start)
+		res.SourceIndex = append(res.SourceIndex, col.last)
+	}
+	return res, nil
+}
@@ -0,0 +1,52 @@
+package indicators
+
+import (
+	"errors"
+)
+
+// PPO (Percentage Price Oscillator) is MACD expressed as a percentage of the slow EMA,
+// which makes values comparable across instruments with different price levels:
+//
+//	PPO = (EMA(fast) - EMA(slow)) / EMA(slow) * 100
+type PPO struct {
+	FastPeriod   int
+	SlowPeriod   int
+	SignalPeriod int
+}
+
+// NewPPO returns a PPO instance, commonly NewPPO(12, 26, 9).
+func NewPPO(fast, slow, signal int) *PPO {
+	return &PPO{FastPeriod: fast, SlowPeriod: slow, SignalPeriod: signal}
+}
+
+// Calculate returns ppoLine, signalLine, histogram.
+func (p *PPO) Calculate(prices []float64) ([]float64, []float64, []float64, error) {
+	if len(prices) < p.SlowPeriod {
+		return nil, nil, nil, errors.New("not enough data for PPO")
+	}
+	fastEMA, err := NewEMA(p.FastPeriod).Calculate(prices)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	slowEMA, err := NewEMA(p.SlowPeriod).Calculate(prices)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	ppoLine := make([]float64, len(prices))
+	for i := 0; i < len(prices); i++ {
+		if slowEMA[i] == 0 {
+			ppoLine[i] = 0
+		} else {
+			ppoLine[i] = (fastEMA[i] - slowEMA[i]) / slowEMA[i] * 100
+		}
+	}
+	signalLine, err := NewEMA(p.SignalPeriod).Calculate(ppoLine)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	hist := make([]float64, len(prices))
+	for i := 0; i < len(prices); i++ {
+		hist[i] = ppoLine[i] - signalLine[i]
+	}
+	return ppoLine, signalLine, hist, nil
+}
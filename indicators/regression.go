@@ -0,0 +1,161 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/stat"
+)
+
+/*
+Rolling Linear Regression:
+-----------------------------------------
+For each bar, a least-squares line is fitted to the last 'window' prices with
+x = 0 (oldest) .. window-1 (current bar):
+
+	Value     = intercept + slope * (window - 1)   (the line at the current bar)
+	Intercept = the line at the oldest bar of the window
+	Angle     = atan(slope), in degrees
+	RSquared  = coefficient of determination of the fit
+	StdErr    = sqrt( sum(residual^2) / (window - 2) )
+	Upper     = Value + numStdErr * StdErr
+	Lower     = Value - numStdErr * StdErr
+	Forecast  = intercept + slope * window        (Time Series Forecast, one bar ahead)
+
+Slope is a trend filter (sign and steepness), R-squared a regime indicator
+(near 1 = clean trend, near 0 = noise).
+-----------------------------------------
+*/
+type LinearRegression struct {
+	Window    int
+	NumStdErr float64 // width of the standard error bands, e.g. 2
+}
+
+// LinearRegressionResult holds every rolling regression series.
+// All slices have the same length as the input; the first (Window-1) values are math.NaN().
+type LinearRegressionResult struct {
+	Value     []float64
+	Slope     []float64
+	Intercept []float64
+	Angle     []float64
+	RSquared  []float64
+	StdErr    []float64
+	Upper     []float64
+	Lower     []float64
+	Forecast  []float64
+}
+
+// NewLinearRegression returns a LinearRegression with the given window and band width.
+func NewLinearRegression(window int, numStdErr float64) *LinearRegression {
+	return &LinearRegression{Window: window, NumStdErr: numStdErr}
+}
+
+// Calculate returns the regression line value at each bar, so LinearRegression
+// can be used anywhere a moving average is expected.
+func (l *LinearRegression) Calculate(prices []float64) ([]float64, error) {
+	res, err := l.CalculateAll(prices)
+	if err != nil {
+		return nil, err
+	}
+	return res.Value, nil
+}
+
+// CalculateAll returns value, slope, intercept, angle, R-squared, standard error,
+// standard error bands and the time series forecast.
+func (l *LinearRegression) CalculateAll(prices []float64) (*LinearRegressionResult, error) {
+	if l.Window < 2 {
+		return nil, errors.New("window must be >= 2 for LinearRegression")
+	}
+	n := len(prices)
+	if n < l.Window {
+		return nil, errors.New("not enough data for LinearRegression")
+	}
+
+	res := &LinearRegressionResult{
+		Value:     make([]float64, n),
+		Slope:     make([]float64, n),
+		Intercept: make([]float64, n),
+		Angle:     make([]float64, n),
+		RSquared:  make([]float64, n),
+		StdErr:    make([]float64, n),
+		Upper:     make([]float64, n),
+		Lower:     make([]float64, n),
+		Forecast:  make([]float64, n),
+	}
+	series := [][]float64{res.Value, res.Slope, res.Intercept, res.Angle, res.RSquared,
+		res.StdErr, res.Upper, res.Lower, res.Forecast}
+	for i := 0; i < l.Window-1; i++ {
+		for _, s := range series {
+			s[i] = math.NaN()
+		}
+	}
+
+	xs := regressionX(l.Window)
+	w := float64(l.Window)
+	for i := l.Window - 1; i < n; i++ {
+		ys := prices[i-l.Window+1 : i+1]
+		intercept, slope := stat.LinearRegression(xs, ys, nil, false)
+
+		var sse float64
+		for j, y := range ys {
+			r := y - (intercept + slope*xs[j])
+			sse += r * r
+		}
+		stdErr := 0.0
+		if l.Window > 2 {
+			stdErr = math.Sqrt(sse / (w - 2))
+		}
+		rSquared := 1.0
+		if sse != 0 {
+			rSquared = stat.RSquared(xs, ys, nil, intercept, slope)
+		}
+
+		value := intercept + slope*(w-1)
+		res.Value[i] = value
+		res.Slope[i] = slope
+		res.Intercept[i] = intercept
+		res.Angle[i] = math.Atan(slope) * 180 / math.Pi
+		res.RSquared[i] = rSquared
+		res.StdErr[i] = stdErr
+		res.Upper[i] = value + l.NumStdErr*stdErr
+		res.Lower[i] = value - l.NumStdErr*stdErr
+		res.Forecast[i] = intercept + slope*w
+	}
+	return res, nil
+}
+
+// TimeSeriesForecast (TSF) projects the rolling regression line one bar ahead.
+type TimeSeriesForecast struct {
+	Window int
+}
+
+// NewTimeSeriesForecast returns a TimeSeriesForecast with the given window (often 14).
+func NewTimeSeriesForecast(window int) *TimeSeriesForecast {
+	return &TimeSeriesForecast{Window: window}
+}
+
+// Calculate returns the forecast for the next bar at each index; the first (Window-1) are math.NaN().
+func (t *TimeSeriesForecast) Calculate(prices []float64) ([]float64, error) {
+	res, err := NewLinearRegression(t.Window, 0).CalculateAll(prices)
+	if err != nil {
+		return nil, err
+	}
+	return res.Forecast, nil
+}
+
+// regressionX returns the x coordinates 0..window-1 used for rolling fits.
+func regressionX(window int) []float64 {
+	xs := make([]float64, window)
+	for i := range xs {
+		xs[i] = float64(i)
+	}
+	return xs
+}
+
+// linregEndpoint fits a least-squares line through window (x = 0..n-1)
+// and returns its value at the last point.
+func linregEndpoint(window []float64) float64 {
+	xs := regressionX(len(window))
+	intercept, slope := stat.LinearRegression(xs, window, nil, false)
+	return intercept + slope*float64(len(window)-1)
+}
@@ -0,0 +1,123 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+/*
+Renko Bricks:
+-----------------------------------------
+Bricks are built from closing prices and ignore time. Starting from the first close:
+
+  - a new up brick is drawn each time the close rises one box above the top of the last brick
+  - a new down brick is drawn each time the close falls one box below the bottom of the last brick
+
+so continuing a trend takes one box and reversing it takes two.
+
+Box size is either fixed (BoxSize) or, when ATRWindow > 0, the ATR of the bar on which
+the brick forms. The ATR box only uses data up to that bar, so the bricks never look
+ahead; bars before the ATR warm-up are skipped.
+
+Each brick is returned as a bar (Open/Close are the brick ends, High/Low its range),
+stamped with the time of the source bar that completed it. Volume, when present, is
+the volume traded since the previous brick and is credited to the first brick formed.
+-----------------------------------------
+*/
+type Renko struct {
+	BoxSize   float64
+	ATRWindow int // > 0 selects ATR-sized bricks instead of BoxSize
+}
+
+// RenkoResult holds the bricks and how they map back to the source bars.
+type RenkoResult struct {
+	Bars        *OHLCV
+	Direction   []int // 1 for up bricks, -1 for down bricks
+	SourceIndex []int // index of the source bar that completed each brick
+}
+
+// NewRenko returns a Renko transform with a fixed box size.
+func NewRenko(boxSize float64) *Renko {
+	return &Renko{BoxSize: boxSize}
+}
+
+// NewATRRenko returns a Renko transform whose box size is the ATR over the given window.
+func NewATRRenko(atrWindow int) *Renko {
+	return &Renko{ATRWindow: atrWindow}
+}
+
+// Calculate converts bars into Renko bricks. The result may be empty if prices never
+// move a full box.
+func (r *Renko) Calculate(bars *OHLCV) (*RenkoResult, error) {
+	if err := bars.Validate(); err != nil {
+		return nil, err
+	}
+	n := bars.Len()
+
+	boxes := make([]float64, n)
+	start := 0
+	if r.ATRWindow > 0 {
+		atr, err := NewATR(r.ATRWindow).Calculate(bars.High, bars.Low, bars.Close)
+		if err != nil {
+			return nil, err
+		}
+		copy(boxes, atr)
+		start = r.ATRWindow - 1
+	} else {
+		if r.BoxSize <= 0 {
+			return nil, errors.New("box size must be > 0 for Renko")
+		}
+		for i := range boxes {
+			boxes[i] = r.BoxSize
+		}
+	}
+
+	res := &RenkoResult{Bars: &OHLCV{}}
+	hasTime := len(bars.Time) != 0
+	hasVolume := len(bars.Volume) != 0
+
+	top, bottom := bars.Close[start], bars.Close[start]
+	var volume float64
+	for i := start; i < n; i++ {
+		if hasVolume {
+			volume += bars.Volume[i]
+		}
+		box := boxes[i]
+		if box <= 0 {
+			continue
+		}
+		c := bars.Close[i]
+		for {
+			var open, close float64
+			var dir int
+			switch {
+			case c >= top+box:
+				open, close, dir = top, top+box, 1
+			case c <= bottom-box:
+				open, close, dir = bottom, bottom-box, -1
+			default:
+				dir = 0
+			}
+			if dir == 0 {
+				break
+			}
+			top, bottom = math.Max(open, close), math.Min(open, close)
+
+			b := res.Bars
+			b.Open = append(b.Open, open)
+			b.Close = append(b.Close, close)
+			b.High = append(b.High, top)
+			b.Low = append(b.Low, bottom)
+			if hasTime {
+				b.Time = append(b.Time, bars.Time[i])
+			}
+			if hasVolume {
+				b.Volume = append(b.Volume, volume)
+				volume = 0
+			}
+			res.Direction = append(res.Direction, dir)
+			res.SourceIndex = append(res.SourceIndex, i)
+		}
+	}
+	return res, nil
+}
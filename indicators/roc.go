@@ -0,0 +1,45 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+// ROC (Rate of Change) measures the percentage change between the current
+// price and the price 'Window' bars ago:
+//
+//	ROC[i] = (Price[i] - Price[i-Window]) / Price[i-Window] * 100
+type ROC struct {
+	Window int
+}
+
+// NewROC returns a ROC instance with the given lookback (e.g., 10 or 12).
+func NewROC(window int) *ROC {
+	return &ROC{Window: window}
+}
+
+// Calculate returns a slice of ROC values, the same length as prices.
+// The first 'Window' values are math.NaN() since there is no earlier price to compare against.
+func (r *ROC) Calculate(prices []float64) ([]float64, error) {
+	if r.Window < 1 {
+		return nil, errors.New("window must be >= 1 for ROC")
+	}
+	if len(prices) <= r.Window {
+		return nil, errors.New("not enough data for ROC")
+	}
+
+	out := make([]float64, len(prices))
+	for i := 0; i < r.Window; i++ {
+		out[i] = math.NaN()
+	}
+	for i := r.Window; i < len(prices); i++ {
+		prev := prices[i-r.Window]
+		if prev == 0 {
+			// Percentage change from zero is undefined.
+			out[i] = math.NaN()
+		} else {
+			out[i] = (prices[i] - prev) / prev * 100
+		}
+	}
+	return out, nil
+}
@@ -2,20 +2,69 @@ package indicators
 
 import (
 	"errors"
+	"math"
 )
 
+// RSI computes the Relative Strength Index.
+//
+// Smoothing selects how average gains and losses are formed:
+//   - MADefault / MAWilder: Wilder's original smoothing
+//   - MASimple: Cutler's RSI, a simple average of the last Window gains and losses
+//   - MAExponential: EMA-smoothed gains and losses
 type RSI struct {
-	Window int
+	Window    int
+	Smoothing MAType
 }
 
 func NewRSI(window int) *RSI {
 	return &RSI{Window: window}
 }
 
+// NewCutlerRSI returns an RSI that uses simple averages of gains and losses.
+func NewCutlerRSI(window int) *RSI {
+	return &RSI{Window: window, Smoothing: MASimple}
+}
+
+// Calculate returns a slice of RSI values, the same length as prices.
+// The first Window values are 0 because no full window of price changes exists yet.
 func (r *RSI) Calculate(prices []float64) ([]float64, error) {
-	if len(prices) < r.Window {
+	if r.Window < 1 {
+		return nil, errors.New("window must be >= 1 for RSI")
+	}
+	if len(prices) <= r.Window {
 		return nil, errors.New("not enough data for RSI")
 	}
+	gains, losses := gainsLosses(prices)
+	smoothing := r.Smoothing.resolve(MAWilder)
+	avgG, err := smooth(gains, 0, r.Window, smoothing)
+	if err != nil {
+		return nil, err
+	}
+	avgL, err := smooth(losses, 0, r.Window, smoothing)
+	if err != nil {
+		return nil, err
+	}
+
+	// gains[j] and losses[j] describe the move into prices[j+1].
+	rsiVals := make([]float64, len(prices))
+	for i := 0; i < r.Window; i++ {
+		rsiVals[i] = 0
+	}
+	for i := r.Window; i < len(prices); i++ {
+		g, l := avgG[i-1], avgL[i-1]
+		if l == 0 {
+			rsiVals[i] = 100
+		} else {
+			rs := g / l
+			rsiVals[i] = 100.0 - (100.0 / (1.0 + rs))
+		}
+	}
+	return rsiVals, nil
+}
+
+// gainsLosses splits price changes into gains and losses, both non-negative.
+// The returned slices have len(prices)-1 elements; element j is the move from prices[j] to prices[j+1].
+func gainsLosses(prices []float64) ([]float64, []float64) {
 	gains := make([]float64, len(prices)-1)
 	losses := make([]float64, len(prices)-1)
 	for i := 1; i < len(prices); i++ {
@@ -26,30 +75,98 @@ func (r *RSI) Calculate(prices []float64) ([]float64, error) {
 			losses[i-1] = -diff
 		}
 	}
-	rsiVals := make([]float64, len(prices))
-	for i := 0; i < r.Window; i++ {
-		rsiVals[i] = 0
+	return gains, losses
+}
+
+// RSIStream computes the RSI one price at a time. After warm-up it matches Calculate;
+// the first Window values are math.NaN() where Calculate reports 0.
+type RSIStream struct {
+	avgGain *maStream
+	avgLoss *maStream
+	prev    float64
+	started bool
+}
+
+// NewStream returns an RSIStream with this RSI's configuration.
+func (r *RSI) NewStream() (*RSIStream, error) {
+	if r.Window < 1 {
+		return nil, errors.New("window must be >= 1 for RSI")
 	}
-	var sumG, sumL float64
-	for i := 0; i < r.Window; i++ {
-		sumG += gains[i]
-		sumL += losses[i]
-	}
-	avgG := sumG / float64(r.Window)
-	avgL := sumL / float64(r.Window)
-	rs := float64(0)
-	if avgL != 0 {
-		rs = avgG / avgL
-	}
-	rsiVals[r.Window] = 100.0 - (100.0 / (1.0 + rs))
-	for i := r.Window + 1; i < len(prices); i++ {
-		avgG = ((avgG * float64(r.Window-1)) + gains[i-1]) / float64(r.Window)
-		avgL = ((avgL * float64(r.Window-1)) + losses[i-1]) / float64(r.Window)
-		if avgL == 0 {
+	smoothing := r.Smoothing.resolve(MAWilder)
+	avgG, err := newMAStream(smoothing, r.Window)
+	if err != nil {
+		return nil, err
+	}
+	avgL, err := newMAStream(smoothing, r.Window)
+	if err != nil {
+		return nil, err
+	}
+	return &RSIStream{avgGain: avgG, avgLoss: avgL}, nil
+}
+
+// Update adds a price and returns the current RSI.
+func (st *RSIStream) Update(price float64) float64 {
+	if !st.started {
+		st.prev, st.started = price, true
+		return math.NaN()
+	}
+	diff := price - st.prev
+	st.prev = price
+	gain, loss := 0.0, 0.0
+	if diff > 0 {
+		gain = diff
+	} else {
+		loss = -diff
+	}
+	g := st.avgGain.update(gain)
+	l := st.avgLoss.update(loss)
+	if math.IsNaN(g) {
+		return math.NaN()
+	}
+	if l == 0 {
+		return 100
+	}
+	return 100.0 - (100.0 / (1.0 + g/l))
+}
+
+// AdaptiveCutlerRSI computes Cutler's RSI with a lookback that changes bar by bar,
+// e.g. half the dominant cycle from HilbertDominantCycle. periods[i] is rounded to
+// whole bars, and the value at i is 100 * gains / (gains + losses) over the last
+// periods[i] price changes, so a constant period gives NewCutlerRSI(period). Bars
+// whose period is math.NaN() or longer than the available history are math.NaN().
+func AdaptiveCutlerRSI(prices, periods []float64) ([]float64, error) {
+	if len(periods) != len(prices) {
+		return nil, errors.New("prices and periods must have the same length")
+	}
+	if len(prices) < 2 {
+		return nil, errors.New("not enough data for RSI")
+	}
+	gains, losses := gainsLosses(prices)
+
+	rsiVals := make([]float64, len(prices))
+	for i := range prices {
+		if math.IsNaN(periods[i]) {
+			rsiVals[i] = math.NaN()
+			continue
+		}
+		period := int(periods[i] + 0.5)
+		if period < 1 {
+			return nil, errors.New("adaptive periods must be >= 1 for RSI")
+		}
+		if i < period {
+			rsiVals[i] = math.NaN()
+			continue
+		}
+		// gains[j] and losses[j] describe the move into prices[j+1].
+		var g, l float64
+		for j := i - period; j < i; j++ {
+			g += gains[j]
+			l += losses[j]
+		}
+		if l == 0 {
 			rsiVals[i] = 100
 		} else {
-			rs = avgG / avgL
-			rsiVals[i] = 100.0 - (100.0 / (1.0 + rs))
+			rsiVals[i] = 100.0 - (100.0 / (1.0 + g/l))
 		}
 	}
 	return rsiVals, nil
@@ -29,3 +29,23 @@ func (s *SMA) Calculate(prices []float64) ([]float64, error) {
 	}
 	return out, nil
 }
+
+// SMAStream computes the SMA one value at a time. After warm-up it matches Calculate;
+// during warm-up it returns math.NaN() where Calculate reports 0.
+type SMAStream struct {
+	ma *maStream
+}
+
+// NewStream returns an SMAStream with this SMA's window.
+func (s *SMA) NewStream() (*SMAStream, error) {
+	ma, err := newMAStream(MASimple, s.Window)
+	if err != nil {
+		return nil, err
+	}
+	return &SMAStream{ma: ma}, nil
+}
+
+// Update adds a price and returns the current average.
+func (st *SMAStream) Update(price float64) float64 {
+	return st.ma.update(price)
+}
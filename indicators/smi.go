@@ -0,0 +1,96 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+/*
+SMI (Stochastic Momentum Index):
+-----------------------------------------
+Developed by William Blau. Instead of locating the close within the recent range
+(as %K does), SMI measures the distance of the close from the midpoint of the range
+and double-smooths both numerator and denominator with EMAs:
+
+	M      = (highest high + lowest low) / 2      over kPeriod
+	D      = Close - M
+	R      = highest high - lowest low
+	SMI    = 100 * EMA(EMA(D, first), second) / (0.5 * EMA(EMA(R, first), second))
+	Signal = EMA(SMI, signalPeriod)
+
+SMI ranges from -100 to +100. Common defaults: kPeriod=10, first=3, second=3, signal=10.
+-----------------------------------------
+*/
+type SMI struct {
+	KPeriod         int
+	FirstSmoothing  int
+	SecondSmoothing int
+	SignalPeriod    int
+}
+
+// NewSMI returns an SMI instance with the given range period, smoothing periods and signal period.
+func NewSMI(kPeriod, first, second, signal int) *SMI {
+	return &SMI{
+		KPeriod:         kPeriod,
+		FirstSmoothing:  first,
+		SecondSmoothing: second,
+		SignalPeriod:    signal,
+	}
+}
+
+// Calculate returns the SMI line and its signal line, each the same length as the inputs.
+// The first (KPeriod-1) values are math.NaN().
+func (s *SMI) Calculate(highs, lows, closes []float64) ([]float64, []float64, error) {
+	n := len(highs)
+	if n != len(lows) || n != len(closes) {
+		return nil, nil, errors.New("highs, lows, and closes must have the same length")
+	}
+	if s.KPeriod < 1 || s.FirstSmoothing < 1 || s.SecondSmoothing < 1 || s.SignalPeriod < 1 {
+		return nil, nil, errors.New("periods must be >= 1 for SMI")
+	}
+	if n < s.KPeriod {
+		return nil, nil, errors.New("not enough data for SMI")
+	}
+
+	// Distance from the range midpoint and the range itself, from the first full window.
+	first := s.KPeriod - 1
+	dist := make([]float64, n-first)
+	rng := make([]float64, n-first)
+	for i := first; i < n; i++ {
+		lowV, highV := lowestHighest(highs, lows, i-s.KPeriod+1, i)
+		dist[i-first] = closes[i] - (highV+lowV)/2
+		rng[i-first] = highV - lowV
+	}
+
+	dsDist, err := doubleSmooth(dist, s.FirstSmoothing, s.SecondSmoothing)
+	if err != nil {
+		return nil, nil, err
+	}
+	dsRange, err := doubleSmooth(rng, s.FirstSmoothing, s.SecondSmoothing)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	smiVals := make([]float64, len(dist))
+	for i := range smiVals {
+		if dsRange[i] == 0 {
+			smiVals[i] = 0
+		} else {
+			smiVals[i] = 100 * dsDist[i] / (0.5 * dsRange[i])
+		}
+	}
+	signal, err := NewEMA(s.SignalPeriod).Calculate(smiVals)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	smiOut := make([]float64, n)
+	signalOut := make([]float64, n)
+	for i := 0; i < first; i++ {
+		smiOut[i] = math.NaN()
+		signalOut[i] = math.NaN()
+	}
+	copy(smiOut[first:], smiVals)
+	copy(signalOut[first:], signal)
+	return smiOut, signalOut, nil
+}
@@ -0,0 +1,104 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+/*
+TTM Squeeze (Bollinger Squeeze):
+-----------------------------------------
+Popularized by John Carter. Volatility contracts when the Bollinger Bands move
+inside the Keltner Channels; the subsequent expansion ("fire") often starts a move.
+
+	Squeeze on  = BB upper < KC upper  and  BB lower > KC lower
+	Fired       = squeeze was on at the previous bar and is off now
+
+The momentum histogram shows the direction the move is likely to take:
+
+	Delta    = Close - ( (highest high + lowest low)/2 + SMA(Close) ) / 2   over momentumWindow
+	Momentum = linear regression of Delta over momentumWindow, evaluated at the current bar
+
+Common defaults: length 20, BB 2.0 standard deviations, KC 1.5 ATR.
+-----------------------------------------
+*/
+type TTMSqueeze struct {
+	Bands          *BollingerBands
+	Channels       *KeltnerChannels
+	MomentumWindow int
+}
+
+// NewTTMSqueeze creates a TTMSqueeze using the same length for the Bollinger Bands,
+// the Keltner Channels (EMA and ATR) and the momentum histogram.
+func NewTTMSqueeze(length int, bbNumStd, kcMult float64) *TTMSqueeze {
+	return &TTMSqueeze{
+		Bands:          NewBollingerBands(length, bbNumStd),
+		Channels:       NewKeltnerChannels(length, length, kcMult),
+		MomentumWindow: length,
+	}
+}
+
+// Calculate returns three slices, each the same length as the inputs:
+//   - squeezeOn[i]: true while the Bollinger Bands are inside the Keltner Channels
+//   - fired[i]:     true on the first bar after a squeeze ends
+//   - momentum[i]:  the momentum histogram; math.NaN() during warm-up
+func (s *TTMSqueeze) Calculate(high, low, close []float64) ([]bool, []bool, []float64, error) {
+	length := len(high)
+	if length != len(low) || length != len(close) {
+		return nil, nil, nil, errors.New("high, low, and close must have the same length")
+	}
+	if s.Bands == nil || s.Channels == nil {
+		return nil, nil, nil, errors.New("bands and channels must be configured for TTMSqueeze")
+	}
+	if s.MomentumWindow < 2 {
+		return nil, nil, nil, errors.New("momentum window must be >= 2 for TTMSqueeze")
+	}
+	if length < 2*s.MomentumWindow-1 {
+		return nil, nil, nil, errors.New("not enough data for TTMSqueeze")
+	}
+
+	_, bbUp, bbLow, err := s.Bands.Calculate(close)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	_, kcUp, kcLow, err := s.Channels.Calculate(high, low, close)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Bands are only comparable once both indicators have warmed up.
+	warmUp := s.Bands.Window - 1
+	if s.Channels.AtrPeriod-1 > warmUp {
+		warmUp = s.Channels.AtrPeriod - 1
+	}
+
+	squeezeOn := make([]bool, length)
+	fired := make([]bool, length)
+	for i := warmUp; i < length; i++ {
+		squeezeOn[i] = bbUp[i] < kcUp[i] && bbLow[i] > kcLow[i]
+		if i > warmUp && squeezeOn[i-1] && !squeezeOn[i] {
+			fired[i] = true
+		}
+	}
+
+	// Momentum histogram
+	w := s.MomentumWindow
+	delta := make([]float64, length)
+	for i := w - 1; i < length; i++ {
+		lowV, highV := lowestHighest(high, low, i-w+1, i)
+		var sum float64
+		for j := i - w + 1; j <= i; j++ {
+			sum += close[j]
+		}
+		delta[i] = close[i] - ((highV+lowV)/2.0+sum/float64(w))/2.0
+	}
+	momentum := make([]float64, length)
+	for i := 0; i < 2*w-2; i++ {
+		momentum[i] = math.NaN()
+	}
+	for i := 2*w - 2; i < length; i++ {
+		momentum[i] = linregEndpoint(delta[i-w+1 : i+1])
+	}
+
+	return squeezeOn, fired, momentum, nil
+}
@@ -0,0 +1,59 @@
+package indicators
+
+import (
+	"errors"
+)
+
+/*
+Stochastic RSI (StochRSI):
+-----------------------------------------
+Developed by Tushar Chande and Stanley Kroll. Applies the stochastic formula
+to RSI values instead of prices:
+
+	RawK = (RSI - lowest RSI) / (highest RSI - lowest RSI) * 100   over stochPeriod
+	%K   = SMA(RawK, kPeriod)
+	%D   = SMA(%K, dPeriod)
+
+Common defaults: rsiPeriod=14, stochPeriod=14, kPeriod=3, dPeriod=3.
+Setting kPeriod=1 returns the unsmoothed StochRSI.
+-----------------------------------------
+*/
+type StochRSI struct {
+	RSIPeriod   int
+	StochPeriod int
+	KPeriod     int
+	DPeriod     int
+}
+
+// NewStochRSI returns a StochRSI instance with the given RSI, stochastic, %K and %D periods.
+func NewStochRSI(rsiPeriod, stochPeriod, kPeriod, dPeriod int) *StochRSI {
+	return &StochRSI{
+		RSIPeriod:   rsiPeriod,
+		StochPeriod: stochPeriod,
+		KPeriod:     kPeriod,
+		DPeriod:     dPeriod,
+	}
+}
+
+// Calculate returns %K and %D slices, each the same length as prices.
+// Values are math.NaN() until enough bars exist for the RSI, stochastic and smoothing windows.
+func (s *StochRSI) Calculate(prices []float64) ([]float64, []float64, error) {
+	if s.RSIPeriod < 1 || s.StochPeriod < 1 || s.KPeriod < 1 || s.DPeriod < 1 {
+		return nil, nil, errors.New("periods must be >= 1 for StochRSI")
+	}
+	if len(prices) < s.RSIPeriod+s.StochPeriod {
+		return nil, nil, errors.New("not enough data for StochRSI")
+	}
+
+	rsiVals, err := NewRSI(s.RSIPeriod).Calculate(prices)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// RSI is first valid at index RSIPeriod; earlier values are placeholders.
+	rawK := stochasticK(rsiVals, rsiVals, rsiVals, s.RSIPeriod, s.StochPeriod, FlatRange100)
+	firstRaw := s.RSIPeriod + s.StochPeriod - 1
+	kVals := rollingMean(rawK, firstRaw, s.KPeriod)
+	dVals := rollingMean(kVals, firstRaw+s.KPeriod-1, s.DPeriod)
+	return kVals, dVals, nil
+}
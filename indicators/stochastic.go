@@ -5,54 +5,194 @@ import (
 	"math"
 )
 
+// FlatRangeBehavior decides what %K reports when the highest high equals the lowest low.
+type FlatRangeBehavior int
+
+const (
+	// FlatRange100 reports 100 (the original behavior).
+	FlatRange100 FlatRangeBehavior = iota
+	// FlatRange50 reports the neutral midpoint, 50.
+	FlatRange50
+	// FlatRange0 reports 0.
+	FlatRange0
+	// FlatRangePrevious repeats the previous %K, or 50 if there is none yet.
+	FlatRangePrevious
+)
+
 // StochasticOscillator computes %K and %D.
+//
+// With KSmoothing <= 1 this is the "fast" stochastic. KSmoothing=3 gives the "slow"
+// stochastic, and any other value the "full" stochastic: the raw %K is smoothed over
+// KSmoothing bars before %D is taken over DPeriod bars.
 type StochasticOscillator struct {
-	KPeriod int
-	DPeriod int
+	KPeriod    int
+	DPeriod    int
+	KSmoothing int               // smoothing applied to raw %K; 0 or 1 means none
+	MAType     MAType            // smoothing for %K and %D; defaults to SMA
+	FlatRange  FlatRangeBehavior // %K when high == low over the window; defaults to 100
 }
 
 func NewStochasticOscillator(k, d int) *StochasticOscillator {
 	return &StochasticOscillator{KPeriod: k, DPeriod: d}
 }
 
+// NewFullStochastic creates a full stochastic with a %K smoothing length,
+// e.g. NewFullStochastic(14, 3, 3) for the classic slow stochastic.
+func NewFullStochastic(k, smoothing, d int) *StochasticOscillator {
+	return &StochasticOscillator{KPeriod: k, DPeriod: d, KSmoothing: smoothing}
+}
+
 // Calculate expects highs, lows, closes (same length).
 func (s *StochasticOscillator) Calculate(highs, lows, closes []float64) ([]float64, []float64, error) {
+	if err := s.validate(); err != nil {
+		return nil, nil, err
+	}
 	if len(highs) < s.KPeriod || len(lows) < s.KPeriod || len(closes) < s.KPeriod {
 		return nil, nil, errors.New("not enough data for Stochastic")
 	}
+	if len(highs) != len(closes) || len(lows) != len(closes) {
+		return nil, nil, errors.New("highs, lows, and closes must have the same length")
+	}
+	maType := s.MAType.resolve(MASimple)
+
+	kVals := stochasticK(highs, lows, closes, 0, s.KPeriod, s.FlatRange)
+	kStart := s.KPeriod - 1
+	if s.KSmoothing > 1 {
+		var err error
+		kVals, err = smooth(kVals, kStart, s.KSmoothing, maType)
+		if err != nil {
+			return nil, nil, err
+		}
+		kStart += s.KSmoothing - 1
+	}
+	dVals, err := smooth(kVals, kStart, s.DPeriod, maType)
+	if err != nil {
+		return nil, nil, err
+	}
+	return kVals, dVals, nil
+}
+
+func (s *StochasticOscillator) validate() error {
+	if s.KPeriod < 1 || s.DPeriod < 1 || s.KSmoothing < 0 {
+		return errors.New("invalid Stochastic periods")
+	}
+	if s.FlatRange < FlatRange100 || s.FlatRange > FlatRangePrevious {
+		return errors.New("unknown flat range behavior")
+	}
+	return nil
+}
+
+// StochasticStream computes the stochastic one bar at a time, producing the
+// same values as Calculate without recomputing the whole history.
+type StochasticStream struct {
+	cfg     StochasticOscillator
+	highs   []float64
+	lows    []float64
+	prevK   float64
+	kSmooth *maStream
+	dSmooth *maStream
+}
+
+// NewStream returns a StochasticStream with this oscillator's configuration.
+func (s *StochasticOscillator) NewStream() (*StochasticStream, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	maType := s.MAType.resolve(MASimple)
+	stream := &StochasticStream{cfg: *s, prevK: math.NaN()}
+	var err error
+	if s.KSmoothing > 1 {
+		if stream.kSmooth, err = newMAStream(maType, s.KSmoothing); err != nil {
+			return nil, err
+		}
+	}
+	if stream.dSmooth, err = newMAStream(maType, s.DPeriod); err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+// Update adds a bar and returns the current %K and %D, which are math.NaN() during warm-up.
+func (st *StochasticStream) Update(high, low, close float64) (float64, float64) {
+	if len(st.highs) == st.cfg.KPeriod {
+		st.highs = append(st.highs[:0], st.highs[1:]...)
+		st.lows = append(st.lows[:0], st.lows[1:]...)
+	}
+	st.highs = append(st.highs, high)
+	st.lows = append(st.lows, low)
+	if len(st.highs) < st.cfg.KPeriod {
+		return math.NaN(), math.NaN()
+	}
+
+	lowV, highV := lowestHighest(st.highs, st.lows, 0, len(st.highs)-1)
+	k := rawStochastic(close, lowV, highV, st.prevK, st.cfg.FlatRange)
+	st.prevK = k
+	if st.kSmooth != nil {
+		k = st.kSmooth.update(k)
+		if math.IsNaN(k) {
+			return k, math.NaN()
+		}
+	}
+	return k, st.dSmooth.update(k)
+}
+
+// stochasticK computes the raw %K = (close - lowest low) / (highest high - lowest low) * 100
+// over 'period' bars, treating everything before index 'start' as unavailable.
+// Values before start+period-1 are math.NaN(). A flat range is handled according to 'flat'.
+func stochasticK(highs, lows, closes []float64, start, period int, flat FlatRangeBehavior) []float64 {
 	kVals := make([]float64, len(closes))
-	dVals := make([]float64, len(closes))
-	for i := 0; i < s.KPeriod-1; i++ {
+	first := start + period - 1
+	for i := 0; i < first && i < len(closes); i++ {
 		kVals[i] = math.NaN()
-		dVals[i] = math.NaN()
-	}
-	for i := s.KPeriod - 1; i < len(closes); i++ {
-		lowV := math.MaxFloat64
-		highV := -math.MaxFloat64
-		for j := i - s.KPeriod + 1; j <= i; j++ {
-			if lows[j] < lowV {
-				lowV = lows[j]
-			}
-			if highs[j] > highV {
-				highV = highs[j]
-			}
+	}
+	for i := first; i < len(closes); i++ {
+		lowV, highV := lowestHighest(highs, lows, i-period+1, i)
+		prev := math.NaN()
+		if i > first {
+			prev = kVals[i-1]
 		}
-		denom := highV - lowV
-		if denom == 0 {
-			kVals[i] = 100
-		} else {
-			kVals[i] = (closes[i] - lowV) / denom * 100
+		kVals[i] = rawStochastic(closes[i], lowV, highV, prev, flat)
+	}
+	return kVals
+}
+
+// rawStochastic locates close within [lowV, highV] on a 0..100 scale.
+// prev is the previous raw %K (NaN if none) for FlatRangePrevious.
+func rawStochastic(close, lowV, highV, prev float64, flat FlatRangeBehavior) float64 {
+	denom := highV - lowV
+	if denom != 0 {
+		return (close - lowV) / denom * 100
+	}
+	switch flat {
+	case FlatRange50:
+		return 50
+	case FlatRange0:
+		return 0
+	case FlatRangePrevious:
+		if math.IsNaN(prev) {
+			return 50
 		}
+		return prev
+	default:
+		return 100
 	}
-	for i := 0; i < s.KPeriod-1+(s.DPeriod-1); i++ {
-		dVals[i] = math.NaN()
+}
+
+// rollingMean computes a simple moving average of 'period' values of data,
+// where the first valid input is at index 'start'. This is how %D is derived from %K.
+// Values before start+period-1 are math.NaN().
+func rollingMean(data []float64, start, period int) []float64 {
+	out := make([]float64, len(data))
+	first := start + period - 1
+	for i := 0; i < first && i < len(data); i++ {
+		out[i] = math.NaN()
 	}
-	for i := s.KPeriod - 1 + (s.DPeriod - 1); i < len(kVals); i++ {
+	for i := first; i < len(data); i++ {
 		var sum float64
-		for j := i - s.DPeriod + 1; j <= i; j++ {
-			sum += kVals[j]
+		for j := i - period + 1; j <= i; j++ {
+			sum += data[j]
 		}
-		dVals[i] = sum / float64(s.DPeriod)
+		out[i] = sum / float64(period)
 	}
-	return kVals, dVals, nil
+	return out
 }
@@ -0,0 +1,93 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+/*
+SuperSmoother and Roofing Filter (John Ehlers):
+-----------------------------------------
+The SuperSmoother is a two-pole Butterworth low-pass filter that removes
+aliasing noise with far less lag than an SMA of comparable smoothness:
+
+	a1 = exp(-1.414*pi / period),  b1 = 2*a1*cos(1.414*pi / period)
+	c2 = b1,  c3 = -a1^2,  c1 = 1 - c2 - c3
+	Filt = c1 * (Price + Price[1]) / 2 + c2 * Filt[1] + c3 * Filt[2]
+
+The Roofing Filter passes only the cycle band: a two-pole high-pass filter
+removes trends longer than highPassPeriod, and a SuperSmoother over
+lowPassPeriod removes noise. Its output oscillates around zero.
+Common defaults: highPassPeriod = 48, lowPassPeriod = 10.
+-----------------------------------------
+*/
+type SuperSmoother struct {
+	Period int
+}
+
+// NewSuperSmoother returns a SuperSmoother with the given critical period (often 10).
+func NewSuperSmoother(period int) *SuperSmoother {
+	return &SuperSmoother{Period: period}
+}
+
+// Calculate returns the filtered series, the same length as prices.
+// The first two values equal the input, which seeds the recursion.
+func (s *SuperSmoother) Calculate(prices []float64) ([]float64, error) {
+	if s.Period < 2 {
+		return nil, errors.New("period must be >= 2 for SuperSmoother")
+	}
+	if len(prices) < 2 {
+		return nil, errors.New("not enough data for SuperSmoother")
+	}
+	return superSmooth(prices, s.Period), nil
+}
+
+// RoofingFilter combines a two-pole high-pass filter with a SuperSmoother.
+type RoofingFilter struct {
+	HighPassPeriod int
+	LowPassPeriod  int
+}
+
+// NewRoofingFilter returns a RoofingFilter passing cycles between lowPass and highPass bars.
+func NewRoofingFilter(highPass, lowPass int) *RoofingFilter {
+	return &RoofingFilter{HighPassPeriod: highPass, LowPassPeriod: lowPass}
+}
+
+// Calculate returns the band-passed series, the same length as prices.
+func (r *RoofingFilter) Calculate(prices []float64) ([]float64, error) {
+	if r.LowPassPeriod < 2 || r.HighPassPeriod <= r.LowPassPeriod {
+		return nil, errors.New("RoofingFilter needs 2 <= lowPassPeriod < highPassPeriod")
+	}
+	n := len(prices)
+	if n < 3 {
+		return nil, errors.New("not enough data for RoofingFilter")
+	}
+
+	angle := 0.707 * 2 * math.Pi / float64(r.HighPassPeriod)
+	alpha1 := (math.Cos(angle) + math.Sin(angle) - 1) / math.Cos(angle)
+	hp := make([]float64, n)
+	for i := 2; i < n; i++ {
+		hp[i] = (1-alpha1/2)*(1-alpha1/2)*(prices[i]-2*prices[i-1]+prices[i-2]) +
+			2*(1-alpha1)*hp[i-1] - (1-alpha1)*(1-alpha1)*hp[i-2]
+	}
+	return superSmooth(hp, r.LowPassPeriod), nil
+}
+
+// superSmooth applies Ehlers' two-pole SuperSmoother filter.
+func superSmooth(data []float64, period int) []float64 {
+	a1 := math.Exp(-1.414 * math.Pi / float64(period))
+	b1 := 2 * a1 * math.Cos(1.414*math.Pi/float64(period))
+	c2 := b1
+	c3 := -a1 * a1
+	c1 := 1 - c2 - c3
+
+	out := make([]float64, len(data))
+	for i := range data {
+		if i < 2 {
+			out[i] = data[i]
+			continue
+		}
+		out[i] = c1*(data[i]+data[i-1])/2 + c2*out[i-1] + c3*out[i-2]
+	}
+	return out
+}
@@ -10,7 +10,7 @@ SuperTrend Indicator:
 
 1) Compute ATR over a chosen period.
 2) Calculate "Basic Upper Band" (UB) and "Basic Lower Band" (LB) for each bar:
-   midPrice = (High + Low) / 2
+   midPrice = (High + Low) / 2   (or hlc3, ohlc4, close; see Source)
    UB = midPrice + multiplier * ATR
    LB = midPrice - multiplier * ATR
 
@@ -36,6 +36,9 @@ an integer "trendDirection": 1 (uptrend) or -1 (downtrend).
 You need:
 - period (for ATR)
 - multiplier (commonly 3.0 or so)
+Optionally:
+- source (hl2 by default, as in the original definition)
+- ATR smoothing (Wilder by default; SMA matches TradingView's "ATR with SMA" option)
 
 Typical usage:
 - superTrend flips below price for uptrend
@@ -44,8 +47,20 @@ Typical usage:
 */
 
 type SuperTrend struct {
-	Period     int
-	Multiplier float64
+	Period       int
+	Multiplier   float64
+	Source       PriceSource // price the bands are centered on; defaults to hl2
+	ATRSmoothing MAType      // ATR averaging; defaults to Wilder's smoothing
+}
+
+// SuperTrendResult holds every SuperTrend series, including flip events.
+type SuperTrendResult struct {
+	Line      []float64
+	Direction []int // 1 = uptrend, -1 = downtrend
+	FinalUB   []float64
+	FinalLB   []float64
+	Buy       []bool // true on bars where the trend flips from down to up
+	Sell      []bool // true on bars where the trend flips from up to down
 }
 
 // NewSuperTrend creates a SuperTrend with given ATR period and multiplier.
@@ -67,32 +82,48 @@ func NewSuperTrend(period int, multiplier float64) *SuperTrend {
 func (s *SuperTrend) Calculate(high, low, close []float64) (
 	[]float64, []int, []float64, []float64, error,
 ) {
+	res, err := s.CalculateSignals(nil, high, low, close)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return res.Line, res.Direction, res.FinalUB, res.FinalLB, nil
+}
+
+// CalculateSignals computes the SuperTrend along with buy/sell flip events.
+// open is only needed when Source is SourceOHLC4 and may be nil otherwise.
+func (s *SuperTrend) CalculateSignals(open, high, low, close []float64) (*SuperTrendResult, error) {
 	length := len(high)
 	if length != len(low) || length != len(close) {
-		return nil, nil, nil, nil, errors.New("high, low, close must have same length")
+		return nil, errors.New("high, low, close must have same length")
 	}
 	if length < s.Period {
-		return nil, nil, nil, nil, errors.New("not enough data for SuperTrend period")
+		return nil, errors.New("not enough data for SuperTrend period")
 	}
 
 	// 1) Compute ATR over the same length
-	// Reusing the existing ATR if you have one; else, implementing quickly:
-	atrValues, err := computeATR(high, low, close, s.Period)
+	atrCalc := &ATR{Window: s.Period, Smoothing: s.ATRSmoothing}
+	atrValues, err := atrCalc.Calculate(high, low, close)
 	if err != nil {
-		return nil, nil, nil, nil, err
+		return nil, err
+	}
+	src, err := s.Source.Values(open, high, low, close)
+	if err != nil {
+		return nil, err
 	}
 
 	superTrendLine := make([]float64, length)
 	trendDirection := make([]int, length)
 	finalUB := make([]float64, length)
 	finalLB := make([]float64, length)
+	buy := make([]bool, length)
+	sell := make([]bool, length)
 
 	// 2) Basic Upper/Lower Band
 	basicUB := make([]float64, length)
 	basicLB := make([]float64, length)
 
 	for i := 0; i < length; i++ {
-		midPrice := (high[i] + low[i]) / 2.0
+		midPrice := src[i]
 		basicUB[i] = midPrice + s.Multiplier*atrValues[i]
 		basicLB[i] = midPrice - s.Multiplier*atrValues[i]
 	}
@@ -127,6 +158,7 @@ func (s *SuperTrend) Calculate(high, low, close []float64) (
 			if close[i] <= finalLB[i] {
 				trendDirection[i] = -1
 				superTrendLine[i] = finalUB[i]
+				sell[i] = true
 			} else {
 				trendDirection[i] = 1
 				superTrendLine[i] = finalLB[i]
@@ -135,6 +167,7 @@ func (s *SuperTrend) Calculate(high, low, close []float64) (
 			if close[i] >= finalUB[i] {
 				trendDirection[i] = 1
 				superTrendLine[i] = finalLB[i]
+				buy[i] = true
 			} else {
 				trendDirection[i] = -1
 				superTrendLine[i] = finalUB[i]
@@ -142,57 +175,92 @@ func (s *SuperTrend) Calculate(high, low, close []float64) (
 		}
 	}
 
-	return superTrendLine, trendDirection, finalUB, finalLB, nil
+	return &SuperTrendResult{
+		Line:      superTrendLine,
+		Direction: trendDirection,
+		FinalUB:   finalUB,
+		FinalLB:   finalLB,
+		Buy:       buy,
+		Sell:      sell,
+	}, nil
 }
 
-// A quick internal ATR calculation to keep SuperTrend self-contained.
-// If you already have an ATR function, you can reuse that instead.
-func computeATR(high, low, close []float64, period int) ([]float64, error) {
-	length := len(high)
-	if length != len(low) || length != len(close) {
-		return nil, errors.New("mismatched slice lengths for ATR")
+// SuperTrendStream computes the SuperTrend one bar at a time. After warm-up it matches
+// CalculateSignals; for the first Period-1 bars, while the ATR is still warming up,
+// the line is math.NaN() and the direction 0.
+type SuperTrendStream struct {
+	cfg       SuperTrend
+	atr       *ATRStream
+	count     int
+	prevClose float64
+	finalUB   float64
+	finalLB   float64
+	direction int
+}
+
+// NewStream returns a SuperTrendStream with this configuration.
+func (s *SuperTrend) NewStream() (*SuperTrendStream, error) {
+	switch s.Source {
+	case SourceHL2, SourceHLC3, SourceOHLC4, SourceClose:
+	default:
+		return nil, errors.New("unknown price source")
 	}
-	if period < 1 || period > length {
-		return nil, errors.New("invalid ATR period")
+	atr, err := (&ATR{Window: s.Period, Smoothing: s.ATRSmoothing}).NewStream()
+	if err != nil {
+		return nil, err
 	}
+	return &SuperTrendStream{cfg: *s, atr: atr}, nil
+}
 
-	// TR array
-	tr := make([]float64, length)
-	// First TR is high[0] - low[0]
-	tr[0] = high[0] - low[0]
-
-	// Compute TR
-	for i := 1; i < length; i++ {
-		range1 := high[i] - low[i]
-		range2 := math.Abs(high[i] - close[i-1])
-		range3 := math.Abs(low[i] - close[i-1])
-		tr[i] = math.Max(range1, math.Max(range2, range3))
+// Update adds a bar and returns the SuperTrend line and direction (1 = uptrend,
+// -1 = downtrend), plus whether the trend flipped up (buy) or down (sell) on this bar.
+// open is only used when Source is SourceOHLC4.
+func (st *SuperTrendStream) Update(open, high, low, close float64) (line float64, direction int, buy, sell bool) {
+	atr := st.atr.Update(high, low, close)
+	if math.IsNaN(atr) {
+		atr = 0 // Calculate centers the bands on the source while the ATR warms up
 	}
-
-	// ATR values
-	atr := make([]float64, length)
-
-	// First ATR can be simple sum of first "period" TR / period or just TR[0].
-	// We'll do Wilder's smoothing to be consistent with typical usage.
-	// For i=0, it's just TR[0].
-	// Then: ATR[i] = ((ATR[i-1] * (period - 1)) + TR[i]) / period
-	sumTR := 0.0
-	// sum up first period TR
-	for i := 0; i < period; i++ {
-		sumTR += tr[i]
+	var mid float64
+	switch st.cfg.Source {
+	case SourceHL2:
+		mid = (high + low) / 2.0
+	case SourceHLC3:
+		mid = (high + low + close) / 3.0
+	case SourceOHLC4:
+		mid = (open + high + low + close) / 4.0
+	case SourceClose:
+		mid = close
 	}
-	atr[period-1] = sumTR / float64(period)
+	basicUB := mid + st.cfg.Multiplier*atr
+	basicLB := mid - st.cfg.Multiplier*atr
 
-	// proceed with Wilder's smoothing
-	for i := period; i < length; i++ {
-		prev := atr[i-1]
-		atr[i] = ((prev * float64(period-1)) + tr[i]) / float64(period)
+	if st.count == 0 {
+		st.finalUB, st.finalLB, st.direction = basicUB, basicLB, 1
+	} else {
+		if st.prevClose <= st.finalUB {
+			st.finalUB = math.Min(basicUB, st.finalUB)
+		} else {
+			st.finalUB = basicUB
+		}
+		if st.prevClose >= st.finalLB {
+			st.finalLB = math.Max(basicLB, st.finalLB)
+		} else {
+			st.finalLB = basicLB
+		}
+		if st.direction == 1 && close <= st.finalLB {
+			st.direction, sell = -1, true
+		} else if st.direction == -1 && close >= st.finalUB {
+			st.direction, buy = 1, true
+		}
 	}
+	st.prevClose = close
+	st.count++
 
-	// fill earliest bars (< period-1) with 0 or partial
-	for i := 0; i < period-1; i++ {
-		atr[i] = 0.0 // or math.NaN()
+	if st.count < st.cfg.Period {
+		return math.NaN(), 0, false, false
 	}
-
-	return atr, nil
+	if st.direction == 1 {
+		return st.finalLB, 1, buy, sell
+	}
+	return st.finalUB, -1, buy, sell
 }
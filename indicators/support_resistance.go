@@ -0,0 +1,128 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+/*
+Swing Support/Resistance:
+-----------------------------------------
+Bar i is a swing high if its high is strictly greater than the highs of the
+'Strength' bars on each side (a swing low likewise for lows), so a swing is only
+known Strength bars after it happens.
+
+Swing prices are sorted and grouped while each price lies within 'Tolerance'
+(a fraction, e.g. 0.005 = 0.5%) of the running cluster average. Each cluster with
+at least 'MinTouches' swings becomes a level at the cluster average; levels below
+the last close are support, the rest resistance.
+-----------------------------------------
+*/
+type SupportResistance struct {
+	Strength   int     // bars on each side of a swing point (e.g. 5)
+	Tolerance  float64 // max relative distance between swings in one level (e.g. 0.005)
+	MinTouches int     // min swings needed to report a level (e.g. 2)
+}
+
+// SRLevel is one support or resistance level.
+type SRLevel struct {
+	Price      float64
+	Touches    int  // number of swing points in the level
+	Support    bool // true if the level is below the last close
+	FirstIndex int  // bar index of the earliest swing in the level
+	LastIndex  int  // bar index of the latest swing in the level
+}
+
+// NewSupportResistance returns a SupportResistance detector.
+func NewSupportResistance(strength int, tolerance float64, minTouches int) *SupportResistance {
+	return &SupportResistance{Strength: strength, Tolerance: tolerance, MinTouches: minTouches}
+}
+
+// Calculate expects highs, lows, closes of equal length and returns the levels
+// sorted by price, lowest first.
+func (s *SupportResistance) Calculate(highs, lows, closes []float64) ([]SRLevel, error) {
+	if len(highs) != len(lows) || len(lows) != len(closes) {
+		return nil, errors.New("highs, lows, and closes must have the same length")
+	}
+	if s.Strength < 1 || s.Tolerance < 0 {
+		return nil, errors.New("invalid support/resistance parameters")
+	}
+	if len(closes) < 2*s.Strength+1 {
+		return nil, errors.New("not enough data for SupportResistance")
+	}
+
+	type swing struct {
+		price float64
+		index int
+	}
+	var swings []swing
+	swingHighs, swingLows := swingPoints(highs, lows, s.Strength, s.Strength)
+	for _, i := range swingHighs {
+		swings = append(swings, swing{highs[i], i})
+	}
+	for _, i := range swingLows {
+		swings = append(swings, swing{lows[i], i})
+	}
+	sort.Slice(swings, func(a, b int) bool { return swings[a].price < swings[b].price })
+
+	last := closes[len(closes)-1]
+	var levels []SRLevel
+	for start := 0; start < len(swings); {
+		sum := swings[start].price
+		first, lastIdx := swings[start].index, swings[start].index
+		end := start + 1
+		for ; end < len(swings); end++ {
+			mean := sum / float64(end-start)
+			if math.Abs(swings[end].price-mean) > s.Tolerance*math.Abs(mean) {
+				break
+			}
+			sum += swings[end].price
+			if swings[end].index < first {
+				first = swings[end].index
+			}
+			if swings[end].index > lastIdx {
+				lastIdx = swings[end].index
+			}
+		}
+		touches := end - start
+		if touches >= s.MinTouches {
+			price := sum / float64(touches)
+			levels = append(levels, SRLevel{
+				Price:      price,
+				Touches:    touches,
+				Support:    price < last,
+				FirstIndex: first,
+				LastIndex:  lastIdx,
+			})
+		}
+		start = end
+	}
+	return levels, nil
+}
+
+// swingPoints returns the indices of swing highs and swing lows: bars whose high
+// (low) is strictly above (below) the 'left' bars before and the 'right' bars after.
+func swingPoints(highs, lows []float64, left, right int) (swingHighs, swingLows []int) {
+	for i := left; i+right < len(highs); i++ {
+		isHigh, isLow := true, true
+		for j := i - left; j <= i+right; j++ {
+			if j == i {
+				continue
+			}
+			if highs[j] >= highs[i] {
+				isHigh = false
+			}
+			if lows[j] <= lows[i] {
+				isLow = false
+			}
+		}
+		if isHigh {
+			swingHighs = append(swingHighs, i)
+		}
+		if isLow {
+			swingLows = append(swingLows, i)
+		}
+	}
+	return swingHighs, swingLows
+}
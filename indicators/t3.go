@@ -9,15 +9,19 @@ T3 Moving Average (Tillson's T3)
 
 Overview:
 -----------
-T3 is an advanced smoothing technique proposed by Tim Tillson.
-It applies multiple sequential EMAs and then combines them
-in a way that depends on a "volume factor" (v).
+T3 is an advanced smoothing technique proposed by Tim Tillson
+("Smoothing Techniques For More Accurate Signals", TASC, January 1998).
+It chains a "generalized DEMA" three times, where the volume factor v
+controls how much of the EMA-of-EMA correction is applied:
+
+	GD(x) = EMA(x) * (1 + v) - EMA(EMA(x)) * v
+	T3(x) = GD(GD(GD(x)))
 
 Common defaults:
 - period = 14 (or 20, etc.)
 - v = 0.7 (or 0.5, 0.8, etc.)
 
-One known formula for T3 uses 6 consecutive EMAs, then combines them:
+Expanding the three GD passes gives a weighted sum of six sequential EMAs:
 
  Let e1 = EMA(prices, period)
      e2 = EMA(e1, period)
@@ -26,43 +30,44 @@ One known formula for T3 uses 6 consecutive EMAs, then combines them:
      e5 = EMA(e4, period)
      e6 = EMA(e5, period)
 
- Then T3 = e6 * c1 + e5 * c2 + e4 * c3 + e3 * c4 + e2 * c5 + e1 * c6
-
- with certain coefficients that are functions of volume factor v.
- A commonly referenced set:
+ T3 = c1*e6 + c2*e5 + c3*e4 + c4*e3
+ with
    c1 = -v^3
    c2 = 3v^2 + 3v^3
    c3 = -6v^2 - 3v - 3v^3
    c4 = 1 + 3v + v^3 + 3v^2
-   (some variations exist)
-But a more typical approach uses a simpler version (the one below).
 
-Implementation Approach:
------------
-We'll do a 6-EMA approach as documented in certain code references:
+This is the formula used by TA-Lib and the major charting packages, and is the default.
 
-  T3 = e6*(1 + v^4)
-        - e5*(4v^4)
-        + e4*(6v^4)
-        - e3*(4v^4)
-        + e2*(v^4)
+Legacy Mode:
+-----------
+Earlier versions of this library combined the EMAs as
 
-(You can find multiple slightly different “T3 formula” references.
-The key idea is that T3 is a weighted combination of multiple
-EMAs for deeper smoothing.)
+  T3 = e6*(1 + v^4) - e5*(4v^4) + e4*(6v^4) - e3*(4v^4) + e2*(v^4)
 
-We need to:
-1) Compute 6 sequential EMAs of 'prices' using the same 'period'.
-2) Combine them using the T3 weighting formula.
+which does not match Tillson's definition. It is kept as T3Legacy so existing
+results can be reproduced.
 
 Note: Because T3 is a multi-layer smoothing, it has a warm-up period
 potentially longer than a single 'period'. Early values might be
-less reliable until the chain of EMAs stabilizes.
+less reliable until the chain of EMAs stabilizes. Like EMA, each layer
+is seeded with its first input value.
 */
 
+// T3Mode selects the coefficient set used to combine the EMAs.
+type T3Mode int
+
+const (
+	// T3Tillson uses Tillson's published c1..c4 coefficients on e3..e6.
+	T3Tillson T3Mode = iota
+	// T3Legacy reproduces this library's original (non-standard) combination.
+	T3Legacy
+)
+
 type T3 struct {
 	Period       int     // the EMA period
 	VolumeFactor float64 // the volume factor (v), often between 0.5 and 0.8
+	Mode         T3Mode  // coefficient set; defaults to Tillson's formula
 }
 
 // NewT3 returns a T3 instance with a specified period and volume factor.
@@ -112,15 +117,26 @@ func (t *T3) Calculate(prices []float64) ([]float64, error) {
 
 	t3vals := make([]float64, n)
 	v := t.VolumeFactor
-	// Weighted combination:
-	// T3 = e6*(1 + v^4) - e5*(4v^4) + e4*(6v^4) - e3*(4v^4) + e2*(v^4)
-	// This is a commonly referenced formula.
-	for i := 0; i < n; i++ {
-		t3vals[i] = e6[i]*(1+v*v*v*v) -
-			e5[i]*(4*v*v*v*v) +
-			e4[i]*(6*v*v*v*v) -
-			e3[i]*(4*v*v*v*v) +
-			e2[i]*(v*v*v*v)
+	switch t.Mode {
+	case T3Tillson:
+		c1 := -v * v * v
+		c2 := 3*v*v + 3*v*v*v
+		c3 := -6*v*v - 3*v - 3*v*v*v
+		c4 := 1 + 3*v + v*v*v + 3*v*v
+		for i := 0; i < n; i++ {
+			t3vals[i] = c1*e6[i] + c2*e5[i] + c3*e4[i] + c4*e3[i]
+		}
+	case T3Legacy:
+		// T3 = e6*(1 + v^4) - e5*(4v^4) + e4*(6v^4) - e3*(4v^4) + e2*(v^4)
+		for i := 0; i < n; i++ {
+			t3vals[i] = e6[i]*(1+v*v*v*v) -
+				e5[i]*(4*v*v*v*v) +
+				e4[i]*(6*v*v*v*v) -
+				e3[i]*(4*v*v*v*v) +
+				e2[i]*(v*v*v*v)
+		}
+	default:
+		return nil, errors.New("unknown T3 mode")
 	}
 
 	return t3vals, nil
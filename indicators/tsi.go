@@ -0,0 +1,84 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+/*
+TSI (True Strength Index):
+-----------------------------------------
+Developed by William Blau. Price changes are double-smoothed with EMAs:
+
+	PC       = Price[i] - Price[i-1]
+	DS(PC)   = EMA(EMA(PC, longPeriod), shortPeriod)
+	DS(|PC|) = EMA(EMA(|PC|, longPeriod), shortPeriod)
+	TSI      = 100 * DS(PC) / DS(|PC|)
+	Signal   = EMA(TSI, signalPeriod)
+
+Common defaults: longPeriod=25, shortPeriod=13, signalPeriod=7 (or 13).
+-----------------------------------------
+*/
+type TSI struct {
+	LongPeriod   int
+	ShortPeriod  int
+	SignalPeriod int
+}
+
+// NewTSI returns a TSI instance with the given long, short and signal periods.
+func NewTSI(long, short, signal int) *TSI {
+	return &TSI{LongPeriod: long, ShortPeriod: short, SignalPeriod: signal}
+}
+
+// Calculate returns the TSI line and its signal line, both the same length as prices.
+// The first value is 0 because there is no prior price change; early values are
+// dominated by the EMA warm-up.
+func (t *TSI) Calculate(prices []float64) ([]float64, []float64, error) {
+	if t.LongPeriod < 1 || t.ShortPeriod < 1 || t.SignalPeriod < 1 {
+		return nil, nil, errors.New("periods must be >= 1 for TSI")
+	}
+	if len(prices) <= t.LongPeriod {
+		return nil, nil, errors.New("not enough data for TSI")
+	}
+
+	n := len(prices)
+	pc := make([]float64, n)
+	absPC := make([]float64, n)
+	for i := 1; i < n; i++ {
+		pc[i] = prices[i] - prices[i-1]
+		absPC[i] = math.Abs(pc[i])
+	}
+
+	ds, err := doubleSmooth(pc, t.LongPeriod, t.ShortPeriod)
+	if err != nil {
+		return nil, nil, err
+	}
+	dsAbs, err := doubleSmooth(absPC, t.LongPeriod, t.ShortPeriod)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tsi := make([]float64, n)
+	for i := 0; i < n; i++ {
+		if dsAbs[i] == 0 {
+			tsi[i] = 0
+		} else {
+			tsi[i] = 100 * ds[i] / dsAbs[i]
+		}
+	}
+
+	signal, err := NewEMA(t.SignalPeriod).Calculate(tsi)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tsi, signal, nil
+}
+
+// doubleSmooth applies EMA(EMA(data, first), second).
+func doubleSmooth(data []float64, first, second int) ([]float64, error) {
+	e1, err := NewEMA(first).Calculate(data)
+	if err != nil {
+		return nil, err
+	}
+	return NewEMA(second).Calculate(e1)
+}
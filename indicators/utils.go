@@ -1,9 +1,48 @@
 // indicators/utils.go
 package indicators
 
+import "math"
+
 func max(a, b float64) float64 {
 	if a > b {
 		return a
 	}
 	return b
 }
+
+// lowestHighest returns the lowest low and the highest high over lows/highs[start..end] inclusive.
+func lowestHighest(highs, lows []float64, start, end int) (float64, float64) {
+	lowV := math.MaxFloat64
+	highV := -math.MaxFloat64
+	for j := start; j <= end; j++ {
+		if lows[j] < lowV {
+			lowV = lows[j]
+		}
+		if highs[j] > highV {
+			highV = highs[j]
+		}
+	}
+	return lowV, highV
+}
+
+// highest returns the maximum of data[start..end] and its first index.
+func highest(data []float64, start, end int) (float64, int) {
+	v, idx := data[start], start
+	for i := start + 1; i <= end; i++ {
+		if data[i] > v {
+			v, idx = data[i], i
+		}
+	}
+	return v, idx
+}
+
+// lowest returns the minimum of data[start..end] and its first index.
+func lowest(data []float64, start, end int) (float64, int) {
+	v, idx := data[start], start
+	for i := start + 1; i <= end; i++ {
+		if data[i] < v {
+			v, idx = data[i], i
+		}
+	}
+	return v, idx
+}
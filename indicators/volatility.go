@@ -0,0 +1,167 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+/*
+Historical Volatility Estimators:
+-----------------------------------------
+Each estimator produces a per-bar variance that is averaged over a rolling window
+and annualized:  vol = sqrt(annualizationFactor * variance).
+
+Close-to-close:   sample variance of ln(C[i] / C[i-1])
+Parkinson:        mean( ln(H/L)^2 ) / (4 ln 2)
+Garman-Klass:     mean( 0.5 ln(H/L)^2 - (2 ln 2 - 1) ln(C/O)^2 )
+Rogers-Satchell:  mean( ln(H/C) ln(H/O) + ln(L/C) ln(L/O) )
+Yang-Zhang:       var(overnight) + k var(open-to-close) + (1 - k) RogersSatchell
+                  overnight = ln(O[i] / C[i-1]), open-to-close = ln(C[i] / O[i])
+                  k = 0.34 / (1.34 + (n + 1) / (n - 1))
+
+The annualization factor is the number of bars per year: 252 for daily equity bars,
+365 for crypto, or e.g. 252*78 for 5-minute bars in a 6.5 hour session.
+-----------------------------------------
+*/
+
+// VolatilityEstimator selects the formula used by HistoricalVolatility.
+type VolatilityEstimator int
+
+const (
+	VolatilityCloseToClose VolatilityEstimator = iota
+	VolatilityParkinson
+	VolatilityGarmanKlass
+	VolatilityRogersSatchell
+	VolatilityYangZhang
+)
+
+// HistoricalVolatility computes annualized volatility over a rolling window.
+type HistoricalVolatility struct {
+	Window              int
+	Estimator           VolatilityEstimator
+	AnnualizationFactor float64
+}
+
+// NewHistoricalVolatility returns a HistoricalVolatility with the given window, estimator
+// and annualization factor (bars per year, e.g. 252).
+func NewHistoricalVolatility(window int, estimator VolatilityEstimator, annualization float64) *HistoricalVolatility {
+	return &HistoricalVolatility{
+		Window:              window,
+		Estimator:           estimator,
+		AnnualizationFactor: annualization,
+	}
+}
+
+// Calculate expects open, high, low, close slices of equal length and returns a slice of
+// annualized volatility (as a fraction, e.g. 0.2 for 20%). Close-to-close and Parkinson
+// don't use open, which may then be nil. Estimators that need the previous close
+// (close-to-close, Yang-Zhang) start at index Window; the others at Window-1.
+// Earlier values are math.NaN(). Prices must be positive.
+func (h *HistoricalVolatility) Calculate(open, high, low, close []float64) ([]float64, error) {
+	n := len(close)
+	if n != len(high) || n != len(low) {
+		return nil, errors.New("high, low, and close must have the same length")
+	}
+	needsOpen := h.Estimator == VolatilityGarmanKlass || h.Estimator == VolatilityRogersSatchell ||
+		h.Estimator == VolatilityYangZhang
+	if needsOpen && len(open) != n {
+		return nil, errors.New("open must have the same length as close for this estimator")
+	}
+	if h.Window < 2 {
+		return nil, errors.New("window must be >= 2 for HistoricalVolatility")
+	}
+	if h.AnnualizationFactor <= 0 {
+		return nil, errors.New("annualization factor must be > 0")
+	}
+
+	first := h.Window - 1
+	if h.Estimator == VolatilityCloseToClose || h.Estimator == VolatilityYangZhang {
+		first = h.Window
+	}
+	if n <= first {
+		return nil, errors.New("not enough data for HistoricalVolatility")
+	}
+
+	out := make([]float64, n)
+	for i := 0; i < first; i++ {
+		out[i] = math.NaN()
+	}
+
+	for i := first; i < n; i++ {
+		start := i - h.Window + 1
+		var variance float64
+		switch h.Estimator {
+		case VolatilityCloseToClose:
+			variance = sampleVariance(start, i, func(j int) float64 {
+				return math.Log(close[j] / close[j-1])
+			})
+		case VolatilityParkinson:
+			var sum float64
+			for j := start; j <= i; j++ {
+				hl := math.Log(high[j] / low[j])
+				sum += hl * hl
+			}
+			variance = sum / (float64(h.Window) * 4 * math.Ln2)
+		case VolatilityGarmanKlass:
+			var sum float64
+			for j := start; j <= i; j++ {
+				hl := math.Log(high[j] / low[j])
+				co := math.Log(close[j] / open[j])
+				sum += 0.5*hl*hl - (2*math.Ln2-1)*co*co
+			}
+			variance = sum / float64(h.Window)
+		case VolatilityRogersSatchell:
+			variance = rogersSatchellVariance(open, high, low, close, start, i)
+		case VolatilityYangZhang:
+			overnight := sampleVariance(start, i, func(j int) float64 {
+				return math.Log(open[j] / close[j-1])
+			})
+			openClose := sampleVariance(start, i, func(j int) float64 {
+				return math.Log(close[j] / open[j])
+			})
+			w := float64(h.Window)
+			k := 0.34 / (1.34 + (w+1)/(w-1))
+			variance = overnight + k*openClose + (1-k)*rogersSatchellVariance(open, high, low, close, start, i)
+		default:
+			return nil, errors.New("unknown volatility estimator")
+		}
+		out[i] = math.Sqrt(h.AnnualizationFactor * math.Max(variance, 0))
+	}
+
+	return out, nil
+}
+
+// CalculateOHLCV is a convenience wrapper around Calculate for an OHLCV series. Open
+// may be empty for the close-to-close and Parkinson estimators.
+func (h *HistoricalVolatility) CalculateOHLCV(bars *OHLCV) ([]float64, error) {
+	if err := bars.Validate(); err != nil {
+		return nil, err
+	}
+	return h.Calculate(bars.Open, bars.High, bars.Low, bars.Close)
+}
+
+// sampleVariance returns the sample (n-1) variance of value(j) for j in [start..end].
+func sampleVariance(start, end int, value func(j int) float64) float64 {
+	count := float64(end - start + 1)
+	var sum float64
+	for j := start; j <= end; j++ {
+		sum += value(j)
+	}
+	mean := sum / count
+	var sq float64
+	for j := start; j <= end; j++ {
+		d := value(j) - mean
+		sq += d * d
+	}
+	return sq / (count - 1)
+}
+
+// rogersSatchellVariance returns the mean Rogers-Satchell term over [start..end].
+func rogersSatchellVariance(open, high, low, close []float64, start, end int) float64 {
+	var sum float64
+	for j := start; j <= end; j++ {
+		sum += math.Log(high[j]/close[j])*math.Log(high[j]/open[j]) +
+			math.Log(low[j]/close[j])*math.Log(low[j]/open[j])
+	}
+	return sum / float64(end-start+1)
+}
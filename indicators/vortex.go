@@ -0,0 +1,75 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+/*
+Vortex Indicator (VI):
+-----------------------------------------
+Developed by Etienne Botes and Douglas Siepman (2010).
+
+	VM+ = |High[i] - Low[i-1]|
+	VM- = |Low[i]  - High[i-1]|
+	VI+ = sum(VM+, period) / sum(TR, period)
+	VI- = sum(VM-, period) / sum(TR, period)
+
+A common period is 14. +VI crossing above -VI signals an emerging uptrend.
+-----------------------------------------
+*/
+type Vortex struct {
+	Period int
+}
+
+// NewVortex returns a Vortex instance with the given period.
+func NewVortex(period int) *Vortex {
+	return &Vortex{Period: period}
+}
+
+// Calculate returns two slices (+VI, -VI), each the same length as the inputs.
+// The first 'Period' values are math.NaN().
+func (v *Vortex) Calculate(highs, lows, closes []float64) ([]float64, []float64, error) {
+	n := len(highs)
+	if n != len(lows) || n != len(closes) {
+		return nil, nil, errors.New("highs, lows, and closes must have the same length")
+	}
+	if v.Period < 1 {
+		return nil, nil, errors.New("period must be >= 1 for Vortex")
+	}
+	if n <= v.Period {
+		return nil, nil, errors.New("not enough data for Vortex")
+	}
+
+	tr := trueRange(highs, lows, closes)
+	vmPlus := make([]float64, n)
+	vmMinus := make([]float64, n)
+	for i := 1; i < n; i++ {
+		vmPlus[i] = math.Abs(highs[i] - lows[i-1])
+		vmMinus[i] = math.Abs(lows[i] - highs[i-1])
+	}
+
+	plusVI := make([]float64, n)
+	minusVI := make([]float64, n)
+	for i := 0; i < v.Period; i++ {
+		plusVI[i] = math.NaN()
+		minusVI[i] = math.NaN()
+	}
+	for i := v.Period; i < n; i++ {
+		var sumTR, sumPlus, sumMinus float64
+		for j := i - v.Period + 1; j <= i; j++ {
+			sumTR += tr[j]
+			sumPlus += vmPlus[j]
+			sumMinus += vmMinus[j]
+		}
+		if sumTR == 0 {
+			plusVI[i] = 0
+			minusVI[i] = 0
+		} else {
+			plusVI[i] = sumPlus / sumTR
+			minusVI[i] = sumMinus / sumTR
+		}
+	}
+
+	return plusVI, minusVI, nil
+}
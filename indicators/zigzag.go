@@ -0,0 +1,160 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+/*
+ZigZag:
+-----------------------------------------
+Connects significant swing highs and lows, ignoring moves smaller than a threshold.
+
+While swinging up, the highest high so far is the candidate swing high. Once the low
+falls 'threshold' below it, that high is confirmed as a pivot and the search flips
+to the lowest low (and vice versa). The threshold is either
+
+	Percent:  extreme * Percent / 100
+	ATR:      ATRMultiplier * ATR[i]   (when ATRWindow > 0)
+
+The most recent pivot is only tentative: it moves while price keeps extending, so
+charts that draw it repaint. Every pivot records ConfirmedIndex, the bar on which it
+became final; using pivots only from that bar on avoids look-ahead.
+-----------------------------------------
+*/
+type ZigZag struct {
+	Percent       float64 // minimum reversal in percent, e.g. 5
+	ATRWindow     int     // > 0 selects an ATR threshold instead of Percent
+	ATRMultiplier float64 // ATR multiple for the threshold, e.g. 3
+}
+
+// ZigZagPivot is one swing point.
+type ZigZagPivot struct {
+	Index          int     // bar of the swing high or low
+	Price          float64 // the high (Kind 1) or low (Kind -1) at Index
+	Kind           int     // 1 for a swing high, -1 for a swing low
+	ConfirmedIndex int     // bar on which the reversal confirmed the pivot; -1 while tentative
+}
+
+// ZigZagResult holds the pivots and per-bar views of them.
+type ZigZagResult struct {
+	Pivots []ZigZagPivot
+	// Value is the pivot price at pivot bars and math.NaN() elsewhere, including the
+	// tentative last pivot (repaints).
+	Value []float64
+	// Confirmed is 1 on the bar a swing high is confirmed, -1 on the bar a swing low
+	// is confirmed, 0 otherwise (never repaints).
+	Confirmed []int
+}
+
+// NewZigZag returns a ZigZag with a percentage threshold.
+func NewZigZag(percent float64) *ZigZag {
+	return &ZigZag{Percent: percent}
+}
+
+// NewATRZigZag returns a ZigZag whose threshold is a multiple of the ATR.
+func NewATRZigZag(atrWindow int, multiplier float64) *ZigZag {
+	return &ZigZag{ATRWindow: atrWindow, ATRMultiplier: multiplier}
+}
+
+// Calculate expects highs, lows, closes of equal length (closes are only used by the ATR).
+func (z *ZigZag) Calculate(highs, lows, closes []float64) (*ZigZagResult, error) {
+	if len(highs) != len(lows) || len(lows) != len(closes) {
+		return nil, errors.New("highs, lows, and closes must have the same length")
+	}
+	n := len(highs)
+	if n < 2 {
+		return nil, errors.New("not enough data for ZigZag")
+	}
+
+	var atr []float64
+	start := 0
+	if z.ATRWindow > 0 {
+		if z.ATRMultiplier <= 0 {
+			return nil, errors.New("ATR multiplier must be > 0 for ZigZag")
+		}
+		var err error
+		atr, err = NewATR(z.ATRWindow).Calculate(highs, lows, closes)
+		if err != nil {
+			return nil, err
+		}
+		start = z.ATRWindow - 1
+	} else if z.Percent <= 0 {
+		return nil, errors.New("percent must be > 0 for ZigZag")
+	}
+	threshold := func(i int, extreme float64) float64 {
+		if atr != nil {
+			return z.ATRMultiplier * atr[i]
+		}
+		return math.Abs(extreme) * z.Percent / 100
+	}
+
+	res := &ZigZagResult{
+		Value:     make([]float64, n),
+		Confirmed: make([]int, n),
+	}
+	confirm := func(index int, price float64, kind, at int) {
+		res.Pivots = append(res.Pivots, ZigZagPivot{Index: index, Price: price, Kind: kind, ConfirmedIndex: at})
+		res.Confirmed[at] = kind
+	}
+
+	// trend 0 = undecided: track both extremes until one side reverses by the threshold
+	trend := 0
+	hi, hiIdx := highs[0], 0
+	lo, loIdx := lows[0], 0
+	for i := 1; i < n; i++ {
+		switch trend {
+		case 0:
+			if highs[i] > hi {
+				hi, hiIdx = highs[i], i
+			}
+			if lows[i] < lo {
+				lo, loIdx = lows[i], i
+			}
+			if i < start {
+				continue
+			}
+			if loIdx < i && highs[i]-lo >= threshold(i, lo) {
+				confirm(loIdx, lo, -1, i)
+				trend = 1
+				hi, hiIdx = highest(highs, loIdx+1, i)
+			} else if hiIdx < i && hi-lows[i] >= threshold(i, hi) {
+				confirm(hiIdx, hi, 1, i)
+				trend = -1
+				lo, loIdx = lowest(lows, hiIdx+1, i)
+			}
+		case 1:
+			if highs[i] > hi {
+				hi, hiIdx = highs[i], i
+			} else if hi-lows[i] >= threshold(i, hi) {
+				confirm(hiIdx, hi, 1, i)
+				trend = -1
+				lo, loIdx = lowest(lows, hiIdx+1, i)
+			}
+		case -1:
+			if lows[i] < lo {
+				lo, loIdx = lows[i], i
+			} else if highs[i]-lo >= threshold(i, lo) {
+				confirm(loIdx, lo, -1, i)
+				trend = 1
+				hi, hiIdx = highest(highs, loIdx+1, i)
+			}
+		}
+	}
+
+	// the extreme of the current swing is the tentative last pivot
+	switch trend {
+	case 1:
+		res.Pivots = append(res.Pivots, ZigZagPivot{Index: hiIdx, Price: hi, Kind: 1, ConfirmedIndex: -1})
+	case -1:
+		res.Pivots = append(res.Pivots, ZigZagPivot{Index: loIdx, Price: lo, Kind: -1, ConfirmedIndex: -1})
+	}
+
+	for i := range res.Value {
+		res.Value[i] = math.NaN()
+	}
+	for _, p := range res.Pivots {
+		res.Value[p.Index] = p.Price
+	}
+	return res, nil
+}
@@ -0,0 +1,316 @@
+package rules
+
+import (
+	"math"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+	"github.com/copyleftdev/indicator-libs/signals"
+)
+
+// param describes a numeric literal argument. def is math.NaN() for required parameters.
+type param struct {
+	name    string
+	def     float64
+	integer bool
+}
+
+// function describes a builtin. Arguments are, in order: an optional source series
+// (when source is set, defaulting to close), 'series' required series arguments, then
+// the numeric parameters.
+type function struct {
+	source bool
+	series int
+	params []param
+	fields []string // outputs of multi-output indicators; the first is the default
+	cond   bool     // true if the single output is a condition
+	eval   func(in *input, series [][]float64, params []float64) ([]value, error)
+}
+
+// required is a bar-count parameter with no default.
+func required(name string) param { return param{name: name, def: math.NaN(), integer: true} }
+
+// optional is a bar-count parameter with a default. A default of 0 means the
+// parameter is off unless given.
+func optional(name string, def float64) param { return param{name: name, def: def, integer: true} }
+
+// factor is a non-integer parameter (multipliers, standard deviations) with a default.
+func factor(name string, def float64) param { return param{name: name, def: def} }
+
+var functions map[string]*function
+
+func init() {
+	functions = map[string]*function{
+		"sma": {source: true, params: []param{required("period")},
+			eval: singleSource(func(src []float64, p []float64) ([]float64, error) {
+				out, err := indicators.NewSMA(int(p[0])).Calculate(src)
+				return mask(out, int(p[0])-1), err
+			})},
+		"ema": {source: true, params: []param{required("period")},
+			eval: singleSource(func(src []float64, p []float64) ([]float64, error) {
+				out, err := indicators.NewEMA(int(p[0])).Calculate(src)
+				return mask(out, int(p[0])-1), err
+			})},
+		"rsi": {source: true, params: []param{required("period")},
+			eval: singleSource(func(src []float64, p []float64) ([]float64, error) {
+				out, err := indicators.NewRSI(int(p[0])).Calculate(src)
+				return mask(out, int(p[0])), err
+			})},
+		"roc": {source: true, params: []param{required("period")},
+			eval: singleSource(func(src []float64, p []float64) ([]float64, error) {
+				return indicators.NewROC(int(p[0])).Calculate(src)
+			})},
+		"mom": {source: true, params: []param{required("period")},
+			eval: singleSource(func(src []float64, p []float64) ([]float64, error) {
+				return indicators.NewMomentum(int(p[0])).Calculate(src)
+			})},
+		"cmo": {source: true, params: []param{required("period")},
+			eval: singleSource(func(src []float64, p []float64) ([]float64, error) {
+				return indicators.NewCMO(int(p[0])).Calculate(src)
+			})},
+		"linreg": {source: true, params: []param{required("period")},
+			eval: singleSource(func(src []float64, p []float64) ([]float64, error) {
+				return indicators.NewLinearRegression(int(p[0]), 0).Calculate(src)
+			})},
+		"macd": {source: true, params: []param{optional("fast", 12), optional("slow", 26), optional("signal", 9)},
+			fields: []string{"macd", "signal", "hist"},
+			eval: func(in *input, s [][]float64, p []float64) ([]value, error) {
+				fast, slow, sig := int(p[0]), int(p[1]), int(p[2])
+				return withSource(s[0], 3, func(src []float64) ([][]float64, error) {
+					m, signal, hist, err := indicators.NewMACD(fast, slow, sig).Calculate(src)
+					return [][]float64{mask(m, slow-1), mask(signal, slow+sig-2), mask(hist, slow+sig-2)}, err
+				})
+			}},
+		"bbands": {source: true, params: []param{optional("period", 20), factor("stddev", 2)},
+			fields: []string{"middle", "upper", "lower"},
+			eval: func(in *input, s [][]float64, p []float64) ([]value, error) {
+				period := int(p[0])
+				return withSource(s[0], 3, func(src []float64) ([][]float64, error) {
+					mid, up, low, err := indicators.NewBollingerBands(period, p[1]).Calculate(src)
+					return [][]float64{mask(mid, period-1), mask(up, period-1), mask(low, period-1)}, err
+				})
+			}},
+		"atr": {params: []param{required("period")},
+			eval: func(in *input, _ [][]float64, p []float64) ([]value, error) {
+				out, err := indicators.NewATR(int(p[0])).Calculate(in.bars.High, in.bars.Low, in.bars.Close)
+				return numbers(err, mask(out, int(p[0])-1))
+			}},
+		"adx": {params: []param{required("period"), optional("adxperiod", 0)},
+			fields: []string{"adx", "plusdi", "minusdi"},
+			eval: func(in *input, _ [][]float64, p []float64) ([]value, error) {
+				// Without adxperiod this is indicators.NewADX, first valid at n-1. An
+				// explicit ADX window seeds ADX from real DX values, so it is first valid
+				// at n+adxperiod-2 rather than averaging the zero-padded warm-up.
+				n, m := int(p[0]), int(p[1])
+				adx, plus, minus, err := (&indicators.ADX{Window: n, ADXWindow: m}).Calculate(in.bars.High, in.bars.Low, in.bars.Close)
+				warm := n - 1
+				if m > 0 {
+					warm = n + m - 2
+				}
+				return numbers(err, mask(adx, warm), mask(plus, n-1), mask(minus, n-1))
+			}},
+		"supertrend": {params: []param{optional("period", 10), factor("multiplier", 3)},
+			fields: []string{"line", "direction"},
+			eval: func(in *input, _ [][]float64, p []float64) ([]value, error) {
+				n := int(p[0])
+				line, dir, _, _, err := indicators.NewSuperTrend(n, p[1]).Calculate(in.bars.High, in.bars.Low, in.bars.Close)
+				direction := make([]float64, len(dir))
+				for i, d := range dir {
+					direction[i] = float64(d)
+				}
+				return numbers(err, mask(line, n-1), mask(direction, n-1))
+			}},
+		"stoch": {params: []param{optional("k", 14), optional("d", 3)},
+			fields: []string{"k", "d"},
+			eval: func(in *input, _ [][]float64, p []float64) ([]value, error) {
+				k, d := int(p[0]), int(p[1])
+				kv, dv, err := indicators.NewStochasticOscillator(k, d).Calculate(in.bars.High, in.bars.Low, in.bars.Close)
+				return numbers(err, mask(kv, k-1), mask(dv, k+d-2))
+			}},
+		"cci": {params: []param{required("period")},
+			eval: func(in *input, _ [][]float64, p []float64) ([]value, error) {
+				out, err := indicators.NewCCI(int(p[0])).Calculate(in.bars.High, in.bars.Low, in.bars.Close)
+				return numbers(err, mask(out, int(p[0])-1))
+			}},
+		"willr": {params: []param{required("period")},
+			eval: func(in *input, _ [][]float64, p []float64) ([]value, error) {
+				out, err := indicators.NewWilliamsR(int(p[0])).Calculate(in.bars.High, in.bars.Low, in.bars.Close)
+				return numbers(err, mask(out, int(p[0])-1))
+			}},
+		"mfi": {params: []param{required("period")},
+			eval: func(in *input, _ [][]float64, p []float64) ([]value, error) {
+				volume, err := in.series("volume")
+				if err != nil {
+					return nil, err
+				}
+				out, err := indicators.NewMFI(int(p[0])).Calculate(in.bars.High, in.bars.Low, in.bars.Close, volume)
+				return numbers(err, mask(out, int(p[0])))
+			}},
+		"obv": {
+			eval: func(in *input, _ [][]float64, _ []float64) ([]value, error) {
+				volume, err := in.series("volume")
+				if err != nil {
+					return nil, err
+				}
+				out, err := indicators.NewOBV().Calculate(in.bars.Close, volume)
+				return numbers(err, out)
+			}},
+		"keltner": {params: []param{optional("period", 20), optional("atrperiod", 10), factor("multiplier", 2)},
+			fields: []string{"middle", "upper", "lower"},
+			eval: func(in *input, _ [][]float64, p []float64) ([]value, error) {
+				n := int(math.Max(p[0], p[1])) - 1
+				mid, up, low, err := indicators.NewKeltnerChannels(int(p[0]), int(p[1]), p[2]).Calculate(in.bars.High, in.bars.Low, in.bars.Close)
+				return numbers(err, mask(mid, n), mask(up, n), mask(low, n))
+			}},
+		"donchian": {params: []param{required("period")},
+			fields: []string{"middle", "upper", "lower"},
+			eval: func(in *input, _ [][]float64, p []float64) ([]value, error) {
+				n := int(p[0])
+				mid, up, low, err := indicators.NewDonchianChannels(n).Calculate(in.bars.High, in.bars.Low)
+				return numbers(err, mask(mid, n-1), mask(up, n-1), mask(low, n-1))
+			}},
+
+		"crossover": {series: 2, cond: true,
+			eval: func(_ *input, s [][]float64, _ []float64) ([]value, error) {
+				out, err := signals.CrossOver(s[0], s[1])
+				return []value{{cond: out, known: available(s, 1)}}, err
+			}},
+		"crossunder": {series: 2, cond: true,
+			eval: func(_ *input, s [][]float64, _ []float64) ([]value, error) {
+				out, err := signals.CrossUnder(s[0], s[1])
+				return []value{{cond: out, known: available(s, 1)}}, err
+			}},
+		"rising": {series: 1, params: []param{required("bars")}, cond: true,
+			eval: func(_ *input, s [][]float64, p []float64) ([]value, error) {
+				return []value{{cond: signals.Rising(s[0], int(p[0])), known: available(s, int(p[0]))}}, nil
+			}},
+		"falling": {series: 1, params: []param{required("bars")}, cond: true,
+			eval: func(_ *input, s [][]float64, p []float64) ([]value, error) {
+				return []value{{cond: signals.Falling(s[0], int(p[0])), known: available(s, int(p[0]))}}, nil
+			}},
+		"highest": {series: 1, params: []param{required("bars")},
+			eval: func(_ *input, s [][]float64, p []float64) ([]value, error) {
+				return numbers(nil, rolling(s[0], int(p[0]), math.Max))
+			}},
+		"lowest": {series: 1, params: []param{required("bars")},
+			eval: func(_ *input, s [][]float64, p []float64) ([]value, error) {
+				return numbers(nil, rolling(s[0], int(p[0]), math.Min))
+			}},
+		"abs": {series: 1,
+			eval: func(_ *input, s [][]float64, _ []float64) ([]value, error) {
+				return numbers(nil, apply(s[0], nil, func(x, _ float64) float64 { return math.Abs(x) }))
+			}},
+		"min": {series: 2,
+			eval: func(_ *input, s [][]float64, _ []float64) ([]value, error) {
+				return numbers(nil, apply(s[0], s[1], math.Min))
+			}},
+		"max": {series: 2,
+			eval: func(_ *input, s [][]float64, _ []float64) ([]value, error) {
+				return numbers(nil, apply(s[0], s[1], math.Max))
+			}},
+	}
+}
+
+// singleSource adapts a one-output indicator on a source series.
+func singleSource(f func(src []float64, params []float64) ([]float64, error)) func(*input, [][]float64, []float64) ([]value, error) {
+	return func(_ *input, s [][]float64, p []float64) ([]value, error) {
+		return withSource(s[0], 1, func(src []float64) ([][]float64, error) {
+			out, err := f(src, p)
+			return [][]float64{out}, err
+		})
+	}
+}
+
+// withSource runs f on the source from its first non-NaN value, so indicators can be
+// nested (e.g. ema(rsi(14), 9)) without the inner warm-up poisoning the outer one,
+// and pads the outputs back to full length.
+func withSource(src []float64, outputs int, f func([]float64) ([][]float64, error)) ([]value, error) {
+	start := 0
+	for start < len(src) && math.IsNaN(src[start]) {
+		start++
+	}
+	res, err := f(src[start:])
+	if err != nil {
+		return nil, err
+	}
+	vals := make([]value, outputs)
+	for k, out := range res {
+		full := make([]float64, len(src))
+		for i := 0; i < start; i++ {
+			full[i] = math.NaN()
+		}
+		copy(full[start:], out)
+		vals[k] = value{num: full}
+	}
+	return vals, nil
+}
+
+func numbers(err error, series ...[]float64) ([]value, error) {
+	if err != nil {
+		return nil, err
+	}
+	vals := make([]value, len(series))
+	for i, s := range series {
+		vals[i] = value{num: s}
+	}
+	return vals, nil
+}
+
+// available reports, for each bar, whether every series has values on that bar and
+// the 'lookback' bars before it.
+func available(series [][]float64, lookback int) []bool {
+	n := len(series[0])
+	out := make([]bool, n)
+	run := 0 // consecutive bars with all series non-NaN
+	for i := 0; i < n; i++ {
+		ok := true
+		for _, s := range series {
+			if math.IsNaN(s[i]) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			run++
+		} else {
+			run = 0
+		}
+		out[i] = run > lookback
+	}
+	return out
+}
+
+// mask replaces the first n values (indicator warm-up, often reported as 0) with NaN.
+func mask(data []float64, n int) []float64 {
+	for i := 0; i < n && i < len(data); i++ {
+		data[i] = math.NaN()
+	}
+	return data
+}
+
+func rolling(data []float64, n int, f func(a, b float64) float64) []float64 {
+	out := make([]float64, len(data))
+	for i := range out {
+		if i < n-1 {
+			out[i] = math.NaN()
+			continue
+		}
+		v := data[i-n+1]
+		for j := i - n + 2; j <= i; j++ {
+			v = f(v, data[j])
+		}
+		out[i] = v
+	}
+	return out
+}
+
+func apply(a, b []float64, f func(x, y float64) float64) []float64 {
+	out := make([]float64, len(a))
+	for i := range a {
+		y := 0.0
+		if b != nil {
+			y = b[i]
+		}
+		out[i] = f(a[i], y)
+	}
+	return out
+}
@@ -0,0 +1,99 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	num  float64
+	pos  int // 1-based column
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of expression"
+	case tokNumber:
+		return fmt.Sprintf("number %s", t.text)
+	case tokIdent:
+		return fmt.Sprintf("%q", t.text)
+	}
+	return fmt.Sprintf("'%s'", t.text)
+}
+
+// operators, longest first so "<=" wins over "<"
+var operators = []string{"<=", ">=", "==", "!=", "&&", "||", "<", ">", "+", "-", "*", "/", "(", ")", "[", "]", ",", ".", "!"}
+
+// tokenize splits src into tokens. Positions are 1-based rune columns, so a
+// multi-byte character counts as one column.
+func tokenize(src string) ([]token, error) {
+	var toks []token
+	i, col := 0, 1
+	// peek returns the rune at byte offset j and its width, or 0 at the end.
+	peek := func(j int) (rune, int) {
+		if j >= len(src) {
+			return 0, 0
+		}
+		return utf8.DecodeRuneInString(src[j:])
+	}
+	for i < len(src) {
+		c, size := peek(i)
+		next, _ := peek(i + size)
+		start, startCol := i, col
+		switch {
+		case c == utf8.RuneError && size == 1:
+			return nil, errorAt(col, "invalid UTF-8 byte %#x", src[i])
+		case unicode.IsSpace(c):
+			i, col = i+size, col+1
+		case isDigit(c) || (c == '.' && isDigit(next)):
+			for i < len(src) && (isDigit(rune(src[i])) || src[i] == '.') {
+				i, col = i+1, col+1
+			}
+			text := src[start:i]
+			v, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, errorAt(startCol, "invalid number %q", text)
+			}
+			toks = append(toks, token{kind: tokNumber, text: text, num: v, pos: startCol})
+		case isIdentStart(c):
+			for i < len(src) && (isIdentStart(rune(src[i])) || isDigit(rune(src[i]))) {
+				i, col = i+1, col+1
+			}
+			toks = append(toks, token{kind: tokIdent, text: strings.ToLower(src[start:i]), pos: startCol})
+		default:
+			matched := false
+			for _, op := range operators {
+				if strings.HasPrefix(src[i:], op) {
+					toks = append(toks, token{kind: tokOp, text: op, pos: col})
+					i, col = i+len(op), col+len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, errorAt(col, "unexpected character %q", c)
+			}
+		}
+	}
+	toks = append(toks, token{kind: tokEOF, pos: col})
+	return toks, nil
+}
+
+// Names and numbers are ASCII; any other character is reported as unexpected.
+func isDigit(c rune) bool      { return '0' <= c && c <= '9' }
+func isIdentStart(c rune) bool { return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '_' }
@@ -0,0 +1,366 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Grammar, lowest precedence first:
+//
+//	expr     = or
+//	or       = and { ("or" | "||") and }
+//	and      = not { ("and" | "&&") not }
+//	not      = ("not" | "!") not | compare
+//	compare  = sum [ ("<" | "<=" | ">" | ">=" | "==" | "!=") sum ]
+//	sum      = product { ("+" | "-") product }
+//	product  = unary { ("*" | "/") unary }
+//	unary    = "-" unary | postfix
+//	postfix  = primary { "." name | "[" integer "]" }
+//	primary  = number | "true" | "false" | name [ "(" [ expr { "," expr } ] ")" ] | "(" expr ")"
+
+type node interface {
+	pos() int
+	String() string
+}
+
+type numberNode struct {
+	val float64
+	p   int
+}
+
+type boolNode struct {
+	val bool
+	p   int
+}
+
+// identNode is a price series such as close or hl2.
+type identNode struct {
+	name string
+	p    int
+}
+
+type callNode struct {
+	name string
+	args []node
+	p    int
+}
+
+type fieldNode struct {
+	x    node
+	name string
+	p    int
+}
+
+type lookbackNode struct {
+	x    node
+	bars int
+	p    int
+}
+
+type unaryNode struct {
+	op string
+	x  node
+	p  int
+}
+
+type binaryNode struct {
+	op   string
+	x, y node
+	p    int
+}
+
+func (n *numberNode) pos() int   { return n.p }
+func (n *boolNode) pos() int     { return n.p }
+func (n *identNode) pos() int    { return n.p }
+func (n *callNode) pos() int     { return n.p }
+func (n *fieldNode) pos() int    { return n.p }
+func (n *lookbackNode) pos() int { return n.p }
+func (n *unaryNode) pos() int    { return n.p }
+func (n *binaryNode) pos() int   { return n.p }
+
+func (n *numberNode) String() string { return strconv.FormatFloat(n.val, 'g', -1, 64) }
+func (n *boolNode) String() string   { return strconv.FormatBool(n.val) }
+func (n *identNode) String() string  { return n.name }
+func (n *callNode) String() string {
+	args := make([]string, len(n.args))
+	for i, a := range n.args {
+		args[i] = a.String()
+	}
+	return n.name + "(" + strings.Join(args, ", ") + ")"
+}
+func (n *fieldNode) String() string    { return n.x.String() + "." + n.name }
+func (n *lookbackNode) String() string { return fmt.Sprintf("%s[%d]", n.x, n.bars) }
+func (n *unaryNode) String() string {
+	if n.op == "not" {
+		return "(not " + n.x.String() + ")"
+	}
+	return "(" + n.op + n.x.String() + ")"
+}
+func (n *binaryNode) String() string {
+	return "(" + n.x.String() + " " + n.op + " " + n.y.String() + ")"
+}
+
+type parser struct {
+	toks []token
+	i    int
+}
+
+func parse(src string) (node, error) {
+	toks, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks}
+	if p.peek().kind == tokEOF {
+		return nil, errorAt(1, "empty expression")
+	}
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, errorAt(t.pos, "unexpected %s", t)
+	}
+	return n, nil
+}
+
+func (p *parser) peek() token { return p.toks[p.i] }
+
+func (p *parser) next() token {
+	t := p.toks[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+// accept consumes the next token if it is one of the given operators or keywords
+// and returns its canonical spelling.
+func (p *parser) accept(ops ...string) (string, int, bool) {
+	t := p.peek()
+	if t.kind != tokOp && t.kind != tokIdent {
+		return "", 0, false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			p.next()
+			return canonical(op), t.pos, true
+		}
+	}
+	return "", 0, false
+}
+
+func canonical(op string) string {
+	switch op {
+	case "&&":
+		return "and"
+	case "||":
+		return "or"
+	case "!":
+		return "not"
+	}
+	return op
+}
+
+func (p *parser) expect(op string) error {
+	t := p.peek()
+	if t.kind != tokOp || t.text != op {
+		return errorAt(t.pos, "expected '%s' but found %s", op, t)
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	x, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, pos, ok := p.accept("or", "||")
+		if !ok {
+			return x, nil
+		}
+		y, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		x = &binaryNode{op: op, x: x, y: y, p: pos}
+	}
+}
+
+func (p *parser) parseAnd() (node, error) {
+	x, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, pos, ok := p.accept("and", "&&")
+		if !ok {
+			return x, nil
+		}
+		y, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		x = &binaryNode{op: op, x: x, y: y, p: pos}
+	}
+}
+
+func (p *parser) parseNot() (node, error) {
+	if op, pos, ok := p.accept("not", "!"); ok {
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{op: op, x: x, p: pos}, nil
+	}
+	return p.parseCompare()
+}
+
+func (p *parser) parseCompare() (node, error) {
+	x, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	op, pos, ok := p.accept("<", "<=", ">", ">=", "==", "!=")
+	if !ok {
+		return x, nil
+	}
+	y, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if _, _, chained := p.accept("<", "<=", ">", ">=", "==", "!="); chained {
+		return nil, errorAt(pos, "comparisons can't be chained; combine them with 'and'")
+	}
+	return &binaryNode{op: op, x: x, y: y, p: pos}, nil
+}
+
+func (p *parser) parseSum() (node, error) {
+	x, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, pos, ok := p.accept("+", "-")
+		if !ok {
+			return x, nil
+		}
+		y, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		x = &binaryNode{op: op, x: x, y: y, p: pos}
+	}
+}
+
+func (p *parser) parseProduct() (node, error) {
+	x, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, pos, ok := p.accept("*", "/")
+		if !ok {
+			return x, nil
+		}
+		y, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		x = &binaryNode{op: op, x: x, y: y, p: pos}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if op, pos, ok := p.accept("-"); ok {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if num, isNum := x.(*numberNode); isNum {
+			return &numberNode{val: -num.val, p: pos}, nil
+		}
+		return &unaryNode{op: op, x: x, p: pos}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (node, error) {
+	x, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, pos, ok := p.accept("."); ok {
+			t := p.next()
+			if t.kind != tokIdent {
+				return nil, errorAt(t.pos, "expected a field name after '.' but found %s", t)
+			}
+			x = &fieldNode{x: x, name: t.text, p: pos}
+			continue
+		}
+		if _, pos, ok := p.accept("["); ok {
+			t := p.next()
+			if t.kind != tokNumber || t.num != float64(int(t.num)) || t.num < 0 {
+				return nil, errorAt(t.pos, "lookback must be a whole number of bars, e.g. close[1]")
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			x = &lookbackNode{x: x, bars: int(t.num), p: pos}
+			continue
+		}
+		return x, nil
+	}
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNumber:
+		return &numberNode{val: t.num, p: t.pos}, nil
+	case tokIdent:
+		switch t.text {
+		case "true", "false":
+			return &boolNode{val: t.text == "true", p: t.pos}, nil
+		case "and", "or", "not":
+			return nil, errorAt(t.pos, "unexpected '%s'", t.text)
+		}
+		if _, _, ok := p.accept("("); !ok {
+			return &identNode{name: t.text, p: t.pos}, nil
+		}
+		call := &callNode{name: t.text, p: t.pos}
+		if _, _, ok := p.accept(")"); ok {
+			return call, nil
+		}
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+			if _, _, ok := p.accept(","); ok {
+				continue
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return call, nil
+		}
+	case tokOp:
+		if t.text == "(" {
+			x, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return x, nil
+		}
+	}
+	return nil, errorAt(t.pos, "unexpected %s", t)
+}
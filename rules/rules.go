@@ -0,0 +1,581 @@
+// Package rules compiles text expressions such as
+//
+//	rsi(14) < 30 and close > supertrend(10, 3).line and adx(14) > 25
+//
+// into conditions evaluated over an indicators.OHLCV series, so screens and
+// strategy rules can be written without recompiling.
+//
+// Expressions support:
+//
+//   - prices: open, high, low, close, volume, hl2, hlc3, ohlc4
+//   - numbers, true/false, arithmetic (+ - * /) and comparisons (< <= > >= == !=)
+//   - boolean logic: and, or, not (also &&, ||, !)
+//   - lookback: close[1] is the previous close, rsi(14)[2] the RSI two bars ago
+//   - indicators: sma, ema, rsi, roc, mom, cmo, linreg, macd, bbands (these take an
+//     optional source series first, e.g. ema(rsi(14), 9)), atr, adx, supertrend,
+//     stoch, cci, willr, mfi, obv, keltner, donchian
+//   - outputs of multi-output indicators: macd(12, 26, 9).signal, bbands(20, 2).upper,
+//     adx(14).plusdi, supertrend(10, 3).direction, stoch(14, 3).d, ...; without a
+//     field the first output is used
+//   - adx(n) matches indicators.NewADX(n), with ADX seeded by averaging DX over the
+//     first n bars; adx(n, m) matches indicators.NewADXWithSmoothing(n, m), seeding
+//     ADX from the first m real DX values so it starts at bar n+m-2
+//   - helpers: crossover(a, b), crossunder(a, b), rising(x, n), falling(x, n),
+//     highest(x, n), lowest(x, n), abs(x), min(a, b), max(a, b)
+//
+// Indicator warm-up bars are NaN. A condition on a bar where any of its inputs is NaN
+// (or, for lookback and crossover/rising/falling, was NaN on the bars it looks back
+// at) is unknown, and unknown bars are false whichever way the condition is negated
+// or combined, so a condition is never true before all of its inputs are available.
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+// Error is a compile or evaluation error with the 1-based column it refers to.
+type Error struct {
+	Column int
+	Msg    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("rules: column %d: %s", e.Column, e.Msg)
+}
+
+func errorAt(col int, format string, args ...interface{}) *Error {
+	return &Error{Column: col, Msg: fmt.Sprintf(format, args...)}
+}
+
+// kind is the static type of an expression.
+type kind int
+
+const (
+	kindNumber kind = iota
+	kindCond
+	kindRecord // multi-output indicator call; behaves as its first output
+)
+
+func (k kind) String() string {
+	if k == kindCond {
+		return "condition"
+	}
+	return "number"
+}
+
+// value is an evaluated series: num for numbers (and a record's default output),
+// cond for conditions, fields for multi-output indicator calls. known marks the bars
+// where a condition's inputs are all available; cond is false wherever known is false,
+// so that negating an unknown bar (e.g. during indicator warm-up) can't make it true.
+type value struct {
+	num    []float64
+	cond   []bool
+	known  []bool
+	fields map[string][]float64
+}
+
+// Rule is a compiled expression.
+type Rule struct {
+	src  string
+	root node
+	kind kind
+}
+
+// Compile parses and type-checks an expression. Unknown names, wrong argument counts,
+// unknown outputs and type mismatches (e.g. "rsi(14) and close") are reported here,
+// before any data is evaluated.
+func Compile(src string) (*Rule, error) {
+	root, err := parse(src)
+	if err != nil {
+		return nil, err
+	}
+	k, err := check(root)
+	if err != nil {
+		return nil, err
+	}
+	return &Rule{src: src, root: root, kind: k}, nil
+}
+
+// String returns the source expression.
+func (r *Rule) String() string {
+	return r.src
+}
+
+// IsCondition reports whether the expression yields true/false values.
+func (r *Rule) IsCondition() bool {
+	return r.kind == kindCond
+}
+
+// Evaluate evaluates a condition over bars and returns one value per bar.
+func (r *Rule) Evaluate(bars *indicators.OHLCV) ([]bool, error) {
+	if r.kind != kindCond {
+		return nil, errorAt(1, "expression is a number, not a condition; compare it with something, e.g. %s > 0", r.src)
+	}
+	v, err := r.eval(bars)
+	if err != nil {
+		return nil, err
+	}
+	return v.cond, nil
+}
+
+// Values evaluates a numeric expression (e.g. "close - sma(20)") over bars.
+func (r *Rule) Values(bars *indicators.OHLCV) ([]float64, error) {
+	if r.kind == kindCond {
+		return nil, errorAt(1, "expression is a condition, not a number")
+	}
+	v, err := r.eval(bars)
+	if err != nil {
+		return nil, err
+	}
+	return v.num, nil
+}
+
+func (r *Rule) eval(bars *indicators.OHLCV) (value, error) {
+	if bars == nil {
+		return value{}, errors.New("rules: no bars to evaluate")
+	}
+	if err := bars.Validate(); err != nil {
+		return value{}, fmt.Errorf("rules: %w", err)
+	}
+	in := &input{bars: bars, n: bars.Len(), cache: make(map[string][]value)}
+	return in.eval(r.root)
+}
+
+var priceNames = []string{"open", "high", "low", "close", "volume", "hl2", "hlc3", "ohlc4"}
+
+func isPrice(name string) bool {
+	for _, p := range priceNames {
+		if p == name {
+			return true
+		}
+	}
+	return false
+}
+
+func check(n node) (kind, error) {
+	switch n := n.(type) {
+	case *numberNode:
+		return kindNumber, nil
+	case *boolNode:
+		return kindCond, nil
+	case *identNode:
+		if isPrice(n.name) {
+			return kindNumber, nil
+		}
+		if _, ok := functions[n.name]; ok {
+			return 0, errorAt(n.p, "%s is a function; call it with arguments, e.g. %s(14)", n.name, n.name)
+		}
+		return 0, errorAt(n.p, "unknown name %q (prices are %s)", n.name, strings.Join(priceNames, ", "))
+	case *callNode:
+		return checkCall(n)
+	case *fieldNode:
+		call, ok := n.x.(*callNode)
+		if !ok {
+			return 0, errorAt(n.p, "only indicator calls have outputs, e.g. macd(12, 26, 9).signal")
+		}
+		if _, err := checkCall(call); err != nil {
+			return 0, err
+		}
+		fn := functions[call.name]
+		if len(fn.fields) == 0 {
+			return 0, errorAt(n.p, "%s has a single output; remove .%s", call.name, n.name)
+		}
+		for _, f := range fn.fields {
+			if f == n.name {
+				return kindNumber, nil
+			}
+		}
+		return 0, errorAt(n.p, "%s has no output %q (available: %s)", call.name, n.name, strings.Join(fn.fields, ", "))
+	case *lookbackNode:
+		k, err := check(n.x)
+		if err != nil {
+			return 0, err
+		}
+		if k == kindRecord {
+			k = kindNumber
+		}
+		return k, nil
+	case *unaryNode:
+		k, err := check(n.x)
+		if err != nil {
+			return 0, err
+		}
+		if n.op == "not" {
+			return kindCond, expect(n.x, k, kindCond, "'not'")
+		}
+		return kindNumber, expect(n.x, k, kindNumber, "'-'")
+	case *binaryNode:
+		kx, err := check(n.x)
+		if err != nil {
+			return 0, err
+		}
+		ky, err := check(n.y)
+		if err != nil {
+			return 0, err
+		}
+		switch n.op {
+		case "and", "or":
+			if err := expect(n.x, kx, kindCond, "'"+n.op+"'"); err != nil {
+				return 0, err
+			}
+			return kindCond, expect(n.y, ky, kindCond, "'"+n.op+"'")
+		case "+", "-", "*", "/":
+			if err := expect(n.x, kx, kindNumber, "'"+n.op+"'"); err != nil {
+				return 0, err
+			}
+			return kindNumber, expect(n.y, ky, kindNumber, "'"+n.op+"'")
+		default:
+			if err := expect(n.x, kx, kindNumber, "'"+n.op+"'"); err != nil {
+				return 0, err
+			}
+			return kindCond, expect(n.y, ky, kindNumber, "'"+n.op+"'")
+		}
+	}
+	return 0, errorAt(n.pos(), "unsupported expression")
+}
+
+func expect(n node, got, want kind, context string) error {
+	if got == kindRecord {
+		got = kindNumber
+	}
+	if got != want {
+		return errorAt(n.pos(), "%s expects a %s but %s is a %s", context, want, n, got)
+	}
+	return nil
+}
+
+// splitArgs separates a call's series arguments from its numeric parameters.
+func splitArgs(n *callNode, fn *function) (series []node, params []node) {
+	args := n.args
+	if fn.source && len(args) > 0 {
+		if _, isNum := args[0].(*numberNode); !isNum {
+			series, args = append(series, args[0]), args[1:]
+		}
+	}
+	if fn.series > 0 && len(args) >= fn.series {
+		series, args = append(series, args[:fn.series]...), args[fn.series:]
+	}
+	return series, args
+}
+
+func checkCall(n *callNode) (kind, error) {
+	fn, ok := functions[n.name]
+	if !ok {
+		if isPrice(n.name) {
+			return 0, errorAt(n.p, "%s is a price, not a function; use %s or %s[1]", n.name, n.name, n.name)
+		}
+		return 0, errorAt(n.p, "unknown function %q (available: %s)", n.name, strings.Join(functionNames(), ", "))
+	}
+	series, params := splitArgs(n, fn)
+	if len(series) < fn.series {
+		return 0, errorAt(n.p, "%s expects %s", n.name, usage(n.name, fn))
+	}
+	requiredParams := 0
+	for _, p := range fn.params {
+		if math.IsNaN(p.def) {
+			requiredParams++
+		}
+	}
+	if len(params) < requiredParams || len(params) > len(fn.params) {
+		return 0, errorAt(n.p, "%s expects %s", n.name, usage(n.name, fn))
+	}
+	for _, s := range series {
+		k, err := check(s)
+		if err != nil {
+			return 0, err
+		}
+		if err := expect(s, k, kindNumber, n.name); err != nil {
+			return 0, err
+		}
+	}
+	for i, a := range params {
+		num, isNum := a.(*numberNode)
+		p := fn.params[i]
+		if !isNum {
+			return 0, errorAt(a.pos(), "%s %s must be a number, e.g. %s", n.name, p.name, usage(n.name, fn))
+		}
+		if p.integer && (num.val < 1 || num.val != math.Trunc(num.val)) {
+			return 0, errorAt(a.pos(), "%s %s must be a positive whole number, got %s", n.name, p.name, num)
+		}
+		if num.val <= 0 {
+			return 0, errorAt(a.pos(), "%s %s must be positive, got %s", n.name, p.name, num)
+		}
+	}
+	switch {
+	case len(fn.fields) > 0:
+		return kindRecord, nil
+	case fn.cond:
+		return kindCond, nil
+	}
+	return kindNumber, nil
+}
+
+func usage(name string, fn *function) string {
+	var args []string
+	if fn.source {
+		args = append(args, "[source]")
+	}
+	for i := 0; i < fn.series; i++ {
+		args = append(args, "series")
+	}
+	for _, p := range fn.params {
+		switch {
+		case math.IsNaN(p.def):
+			args = append(args, p.name)
+		case p.def == 0:
+			args = append(args, "["+p.name+"]")
+		default:
+			args = append(args, fmt.Sprintf("%s=%g", p.name, p.def))
+		}
+	}
+	return name + "(" + strings.Join(args, ", ") + ")"
+}
+
+func functionNames() []string {
+	names := make([]string, 0, len(functions))
+	for name := range functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type input struct {
+	bars  *indicators.OHLCV
+	n     int
+	cache map[string][]value // indicator calls by canonical text
+}
+
+// series returns a price series by name.
+func (in *input) series(name string) ([]float64, error) {
+	b := in.bars
+	var src indicators.PriceSource
+	switch name {
+	case "open":
+		if len(b.Open) == 0 {
+			return nil, fmt.Errorf("rules: the expression uses open but the bars have no open prices")
+		}
+		return b.Open, nil
+	case "high":
+		return b.High, nil
+	case "low":
+		return b.Low, nil
+	case "close":
+		return b.Close, nil
+	case "volume":
+		if len(b.Volume) == 0 {
+			return nil, fmt.Errorf("rules: the expression uses volume but the bars have no volume")
+		}
+		return b.Volume, nil
+	case "hl2":
+		src = indicators.SourceHL2
+	case "hlc3":
+		src = indicators.SourceHLC3
+	case "ohlc4":
+		if len(b.Open) == 0 {
+			return nil, fmt.Errorf("rules: the expression uses ohlc4 but the bars have no open prices")
+		}
+		src = indicators.SourceOHLC4
+	default:
+		return nil, fmt.Errorf("rules: unknown price %q", name)
+	}
+	return src.Values(b.Open, b.High, b.Low, b.Close)
+}
+
+func (in *input) constant(v float64) []float64 {
+	out := make([]float64, in.n)
+	for i := range out {
+		out[i] = v
+	}
+	return out
+}
+
+func (in *input) eval(n node) (value, error) {
+	switch n := n.(type) {
+	case *numberNode:
+		return value{num: in.constant(n.val)}, nil
+	case *boolNode:
+		out := make([]bool, in.n)
+		known := make([]bool, in.n)
+		for i := range out {
+			out[i], known[i] = n.val, true
+		}
+		return value{cond: out, known: known}, nil
+	case *identNode:
+		s, err := in.series(n.name)
+		return value{num: s}, err
+	case *callNode:
+		return in.call(n)
+	case *fieldNode:
+		v, err := in.eval(n.x)
+		if err != nil {
+			return value{}, err
+		}
+		return value{num: v.fields[n.name]}, nil
+	case *lookbackNode:
+		v, err := in.eval(n.x)
+		if err != nil {
+			return value{}, err
+		}
+		return shift(v, n.bars), nil
+	case *unaryNode:
+		v, err := in.eval(n.x)
+		if err != nil {
+			return value{}, err
+		}
+		if n.op == "not" {
+			out := make([]bool, in.n)
+			for i, c := range v.cond {
+				out[i] = v.known[i] && !c
+			}
+			return value{cond: out, known: v.known}, nil
+		}
+		out := make([]float64, in.n)
+		for i, x := range v.num {
+			out[i] = -x
+		}
+		return value{num: out}, nil
+	case *binaryNode:
+		x, err := in.eval(n.x)
+		if err != nil {
+			return value{}, err
+		}
+		y, err := in.eval(n.y)
+		if err != nil {
+			return value{}, err
+		}
+		return binary(n.op, x, y), nil
+	}
+	return value{}, errorAt(n.pos(), "unsupported expression")
+}
+
+func (in *input) call(n *callNode) (value, error) {
+	key := n.String()
+	vals, ok := in.cache[key]
+	if !ok {
+		fn := functions[n.name]
+		seriesArgs, paramArgs := splitArgs(n, fn)
+		var series [][]float64
+		if fn.source && len(seriesArgs) == 0 {
+			series = append(series, in.bars.Close)
+		}
+		for _, a := range seriesArgs {
+			v, err := in.eval(a)
+			if err != nil {
+				return value{}, err
+			}
+			series = append(series, v.num)
+		}
+		params := make([]float64, len(fn.params))
+		for i, p := range fn.params {
+			params[i] = p.def
+			if i < len(paramArgs) {
+				params[i] = paramArgs[i].(*numberNode).val
+			}
+		}
+		var err error
+		vals, err = fn.eval(in, series, params)
+		if err != nil {
+			return value{}, errorAt(n.p, "%s: %v", n, err)
+		}
+		in.cache[key] = vals
+	}
+
+	if len(vals) == 1 {
+		return vals[0], nil
+	}
+	fields := make(map[string][]float64, len(vals))
+	for i, name := range functions[n.name].fields {
+		fields[name] = vals[i].num
+	}
+	return value{num: vals[0].num, fields: fields}, nil
+}
+
+// shift moves a series 'bars' bars later: out[i] = v[i-bars]. The first bars are
+// NaN (numbers) or unknown (conditions).
+func shift(v value, bars int) value {
+	if v.cond != nil {
+		out := make([]bool, len(v.cond))
+		known := make([]bool, len(v.cond))
+		for i := bars; i < len(out); i++ {
+			out[i], known[i] = v.cond[i-bars], v.known[i-bars]
+		}
+		return value{cond: out, known: known}
+	}
+	out := make([]float64, len(v.num))
+	for i := range out {
+		if i < bars {
+			out[i] = math.NaN()
+		} else {
+			out[i] = v.num[i-bars]
+		}
+	}
+	return value{num: out}
+}
+
+func binary(op string, x, y value) value {
+	switch op {
+	case "and", "or":
+		out := make([]bool, len(x.cond))
+		known := make([]bool, len(x.cond))
+		for i := range out {
+			known[i] = x.known[i] && y.known[i]
+			if op == "and" {
+				out[i] = known[i] && x.cond[i] && y.cond[i]
+			} else {
+				out[i] = known[i] && (x.cond[i] || y.cond[i])
+			}
+		}
+		return value{cond: out, known: known}
+	case "+", "-", "*", "/":
+		out := make([]float64, len(x.num))
+		for i := range out {
+			a, b := x.num[i], y.num[i]
+			switch op {
+			case "+":
+				out[i] = a + b
+			case "-":
+				out[i] = a - b
+			case "*":
+				out[i] = a * b
+			case "/":
+				if b == 0 {
+					out[i] = math.NaN()
+				} else {
+					out[i] = a / b
+				}
+			}
+		}
+		return value{num: out}
+	}
+	out := make([]bool, len(x.num))
+	known := make([]bool, len(x.num))
+	for i := range out {
+		a, b := x.num[i], y.num[i]
+		if math.IsNaN(a) || math.IsNaN(b) {
+			continue
+		}
+		known[i] = true
+		switch op {
+		case "<":
+			out[i] = a < b
+		case "<=":
+			out[i] = a <= b
+		case ">":
+			out[i] = a > b
+		case ">=":
+			out[i] = a >= b
+		case "==":
+			out[i] = a == b
+		case "!=":
+			out[i] = a != b
+		}
+	}
+	return value{cond: out, known: known}
+}
@@ -0,0 +1,37 @@
+package signals
+
+import "errors"
+
+// BandTouch reports bars whose high reaches the upper band (high >= upper) and bars
+// whose low reaches the lower band (low <= lower), e.g. price touching the Bollinger
+// or Keltner bands. All slices must have the same length.
+func BandTouch(high, low, upper, lower []float64) (upperTouch, lowerTouch []bool, err error) {
+	n := len(high)
+	if len(low) != n || len(upper) != n || len(lower) != n {
+		return nil, nil, errors.New("series must have the same length")
+	}
+	upperTouch = make([]bool, n)
+	lowerTouch = make([]bool, n)
+	for i := 0; i < n; i++ {
+		if !anyNaN(high[i], upper[i]) {
+			upperTouch[i] = high[i] >= upper[i]
+		}
+		if !anyNaN(low[i], lower[i]) {
+			lowerTouch[i] = low[i] <= lower[i]
+		}
+	}
+	return upperTouch, lowerTouch, nil
+}
+
+// BandBreakout reports bars where the close crosses out of the band: above the upper
+// band (breakout up) or below the lower band (breakout down), having been inside it
+// on the previous bar.
+func BandBreakout(close, upper, lower []float64) (up, down []bool, err error) {
+	if up, err = CrossOver(close, upper); err != nil {
+		return nil, nil, err
+	}
+	if down, err = CrossUnder(close, lower); err != nil {
+		return nil, nil, err
+	}
+	return up, down, nil
+}
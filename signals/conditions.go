@@ -0,0 +1,147 @@
+package signals
+
+import (
+	"errors"
+	"math"
+)
+
+// Consecutive returns true at bar i when cond has been true for the last n bars
+// (including i), e.g. "close above SuperTrend for 3 bars".
+func Consecutive(cond []bool, n int) []bool {
+	out := make([]bool, len(cond))
+	run := 0
+	for i, c := range cond {
+		if c {
+			run++
+		} else {
+			run = 0
+		}
+		out[i] = n > 0 && run >= n
+	}
+	return out
+}
+
+// Rising returns true at bar i when values increased on each of the last n bars.
+func Rising(values []float64, n int) []bool {
+	step := make([]bool, len(values))
+	for i := 1; i < len(values); i++ {
+		step[i] = !anyNaN(values[i-1], values[i]) && values[i] > values[i-1]
+	}
+	return Consecutive(step, n)
+}
+
+// Falling returns true at bar i when values decreased on each of the last n bars.
+func Falling(values []float64, n int) []bool {
+	step := make([]bool, len(values))
+	for i := 1; i < len(values); i++ {
+		step[i] = !anyNaN(values[i-1], values[i]) && values[i] < values[i-1]
+	}
+	return Consecutive(step, n)
+}
+
+// BarsSince returns the number of bars since cond was last true (0 on a true bar),
+// or -1 if it has not been true yet.
+func BarsSince(cond []bool) []int {
+	out := make([]int, len(cond))
+	last := -1
+	for i, c := range cond {
+		if c {
+			last = i
+		}
+		if last < 0 {
+			out[i] = -1
+		} else {
+			out[i] = i - last
+		}
+	}
+	return out
+}
+
+// Above returns a[i] > b[i] for each bar (false where either is NaN).
+func Above(a, b []float64) ([]bool, error) {
+	return compare(a, b, func(x, y float64) bool { return x > y })
+}
+
+// Below returns a[i] < b[i] for each bar (false where either is NaN).
+func Below(a, b []float64) ([]bool, error) {
+	return compare(a, b, func(x, y float64) bool { return x < y })
+}
+
+func compare(a, b []float64, f func(x, y float64) bool) ([]bool, error) {
+	if len(a) != len(b) {
+		return nil, errors.New("series must have the same length")
+	}
+	out := make([]bool, len(a))
+	for i := range a {
+		out[i] = !math.IsNaN(a[i]) && !math.IsNaN(b[i]) && f(a[i], b[i])
+	}
+	return out, nil
+}
+
+// And combines conditions bar by bar; all series must have the same length.
+func And(conds ...[]bool) ([]bool, error) {
+	return combine(conds, true, func(acc, c bool) bool { return acc && c })
+}
+
+// Or combines conditions bar by bar; all series must have the same length.
+func Or(conds ...[]bool) ([]bool, error) {
+	return combine(conds, false, func(acc, c bool) bool { return acc || c })
+}
+
+// Not negates a condition.
+func Not(cond []bool) []bool {
+	out := make([]bool, len(cond))
+	for i, c := range cond {
+		out[i] = !c
+	}
+	return out
+}
+
+func combine(conds [][]bool, init bool, f func(acc, c bool) bool) ([]bool, error) {
+	if len(conds) == 0 {
+		return nil, errors.New("no conditions to combine")
+	}
+	n := len(conds[0])
+	out := make([]bool, n)
+	for i := range out {
+		out[i] = init
+	}
+	for _, c := range conds {
+		if len(c) != n {
+			return nil, errors.New("series must have the same length")
+		}
+		for i := range out {
+			out[i] = f(out[i], c[i])
+		}
+	}
+	return out, nil
+}
+
+// Edges turns a state into entry and exit events: entries[i] is true where cond turns
+// on (false before, true now) and exits[i] where it turns off. A state that is already
+// on at bar 0 counts as an entry there, e.g.
+//
+//	entries, exits := Edges(HysteresisBelow(rsi, 30, 50))
+func Edges(cond []bool) (entries, exits []bool) {
+	entries = make([]bool, len(cond))
+	exits = make([]bool, len(cond))
+	prev := false
+	for i, c := range cond {
+		entries[i] = c && !prev
+		exits[i] = !c && prev
+		prev = c
+	}
+	return entries, exits
+}
+
+// Events returns the indices of the bars where cond is true; combine it with Edges
+// to list entry or exit bars.
+func Events(cond []bool) []int {
+	var out []int
+	for i, c := range cond {
+		if c {
+			out = append(out, i)
+		}
+	}
+	return out
+}
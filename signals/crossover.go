@@ -0,0 +1,79 @@
+// Package signals turns indicator outputs into trading conditions: crossovers,
+// threshold crossings with hysteresis, band touches/breakouts and multi-bar
+// conditions. Vectorized functions take whole series and return boolean series
+// aligned with their inputs; the Stream types evaluate the same conditions one bar
+// at a time. math.NaN() inputs (indicator warm-up) never trigger a signal.
+package signals
+
+import (
+	"errors"
+	"math"
+)
+
+// CrossOver returns true at bar i when a moves from at or below b to above it:
+// a[i-1] <= b[i-1] and a[i] > b[i]. Example: MACD crossing above its signal line.
+func CrossOver(a, b []float64) ([]bool, error) {
+	if len(a) != len(b) {
+		return nil, errors.New("series must have the same length")
+	}
+	out := make([]bool, len(a))
+	for i := 1; i < len(a); i++ {
+		out[i] = crossedOver(a[i-1], b[i-1], a[i], b[i])
+	}
+	return out, nil
+}
+
+// CrossUnder returns true at bar i when a moves from at or above b to below it:
+// a[i-1] >= b[i-1] and a[i] < b[i].
+func CrossUnder(a, b []float64) ([]bool, error) {
+	if len(a) != len(b) {
+		return nil, errors.New("series must have the same length")
+	}
+	out := make([]bool, len(a))
+	for i := 1; i < len(a); i++ {
+		out[i] = crossedUnder(a[i-1], b[i-1], a[i], b[i])
+	}
+	return out, nil
+}
+
+// CrossAbove returns true at bar i when values cross above a fixed level,
+// e.g. RSI crossing above 30.
+func CrossAbove(values []float64, level float64) []bool {
+	out := make([]bool, len(values))
+	for i := 1; i < len(values); i++ {
+		out[i] = crossedOver(values[i-1], level, values[i], level)
+	}
+	return out
+}
+
+// CrossBelow returns true at bar i when values cross below a fixed level.
+func CrossBelow(values []float64, level float64) []bool {
+	out := make([]bool, len(values))
+	for i := 1; i < len(values); i++ {
+		out[i] = crossedUnder(values[i-1], level, values[i], level)
+	}
+	return out
+}
+
+func crossedOver(prevA, prevB, a, b float64) bool {
+	if anyNaN(prevA, prevB, a, b) {
+		return false
+	}
+	return prevA <= prevB && a > b
+}
+
+func crossedUnder(prevA, prevB, a, b float64) bool {
+	if anyNaN(prevA, prevB, a, b) {
+		return false
+	}
+	return prevA >= prevB && a < b
+}
+
+func anyNaN(values ...float64) bool {
+	for _, v := range values {
+		if math.IsNaN(v) {
+			return true
+		}
+	}
+	return false
+}
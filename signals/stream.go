@@ -0,0 +1,96 @@
+package signals
+
+import "math"
+
+// CrossStream detects crossovers one bar at a time. It matches CrossOver/CrossUnder
+// on the same data.
+type CrossStream struct {
+	prevA, prevB float64
+	started      bool
+}
+
+// NewCrossStream returns an empty CrossStream.
+func NewCrossStream() *CrossStream {
+	return &CrossStream{}
+}
+
+// Update feeds the next values of a and b (pass the level as b for fixed thresholds)
+// and reports whether a crossed over or under b on this bar.
+func (s *CrossStream) Update(a, b float64) (over, under bool) {
+	if s.started {
+		over = crossedOver(s.prevA, s.prevB, a, b)
+		under = crossedUnder(s.prevA, s.prevB, a, b)
+	}
+	s.prevA, s.prevB, s.started = a, b, true
+	return over, under
+}
+
+// HysteresisStream is the streaming form of HysteresisAbove / HysteresisBelow.
+type HysteresisStream struct {
+	Enter, Exit float64
+	Below       bool // false for HysteresisAbove semantics, true for HysteresisBelow
+	on          bool
+}
+
+// NewHysteresisStream returns a stream that is on above enter until below exit,
+// or, with below set, on below enter until above exit.
+func NewHysteresisStream(enter, exit float64, below bool) *HysteresisStream {
+	return &HysteresisStream{Enter: enter, Exit: exit, Below: below}
+}
+
+// Update feeds the next value and returns the current state.
+func (s *HysteresisStream) Update(v float64) bool {
+	if s.Below {
+		s.on = hysteresisBelow(s.on, v, s.Enter, s.Exit)
+	} else {
+		s.on = hysteresisAbove(s.on, v, s.Enter, s.Exit)
+	}
+	return s.on
+}
+
+// ConsecutiveStream is the streaming form of Consecutive.
+type ConsecutiveStream struct {
+	N   int
+	run int
+}
+
+// NewConsecutiveStream returns a stream that is true once a condition has held for n bars.
+func NewConsecutiveStream(n int) *ConsecutiveStream {
+	return &ConsecutiveStream{N: n}
+}
+
+// Update feeds the next condition value.
+func (s *ConsecutiveStream) Update(cond bool) bool {
+	if cond {
+		s.run++
+	} else {
+		s.run = 0
+	}
+	return s.N > 0 && s.run >= s.N
+}
+
+// BandStream is the streaming form of BandTouch and BandBreakout.
+type BandStream struct {
+	upper, lower *CrossStream
+}
+
+// NewBandStream returns an empty BandStream.
+func NewBandStream() *BandStream {
+	return &BandStream{upper: NewCrossStream(), lower: NewCrossStream()}
+}
+
+// BandEvents holds the band conditions for one bar.
+type BandEvents struct {
+	UpperTouch, LowerTouch   bool
+	BreakoutUp, BreakoutDown bool
+}
+
+// Update feeds the next bar's high, low, close and band values.
+func (s *BandStream) Update(high, low, close, upper, lower float64) BandEvents {
+	var ev BandEvents
+	ev.UpperTouch = !math.IsNaN(high) && !math.IsNaN(upper) && high >= upper
+	ev.LowerTouch = !math.IsNaN(low) && !math.IsNaN(lower) && low <= lower
+	ev.BreakoutUp, _ = s.upper.Update(close, upper)
+	_, ev.BreakoutDown = s.lower.Update(close, lower)
+	return ev
+}
@@ -0,0 +1,56 @@
+package signals
+
+import "math"
+
+// HysteresisAbove returns a state that switches on when values rise to 'enter' or above
+// and stays on until they fall below 'exit' (exit <= enter). The gap between the two
+// levels stops the state from flickering when values hover around a single threshold,
+// e.g. "ADX trending" on at 25 and off below 20. Use Edges for the entry and exit bars.
+func HysteresisAbove(values []float64, enter, exit float64) []bool {
+	out := make([]bool, len(values))
+	on := false
+	for i, v := range values {
+		on = hysteresisAbove(on, v, enter, exit)
+		out[i] = on
+	}
+	return out
+}
+
+// HysteresisBelow returns a state that switches on when values fall to 'enter' or below
+// and stays on until they rise above 'exit' (exit >= enter), e.g. "RSI oversold" on at
+// 30 and off above 50. Use Edges for the entry and exit bars.
+func HysteresisBelow(values []float64, enter, exit float64) []bool {
+	out := make([]bool, len(values))
+	on := false
+	for i, v := range values {
+		on = hysteresisBelow(on, v, enter, exit)
+		out[i] = on
+	}
+	return out
+}
+
+// hysteresisAbove and hysteresisBelow advance the state by one value; NaN leaves
+// the state unchanged.
+func hysteresisAbove(on bool, v, enter, exit float64) bool {
+	switch {
+	case math.IsNaN(v):
+		return on
+	case !on && v >= enter:
+		return true
+	case on && v < exit:
+		return false
+	}
+	return on
+}
+
+func hysteresisBelow(on bool, v, enter, exit float64) bool {
+	switch {
+	case math.IsNaN(v):
+		return on
+	case !on && v <= enter:
+		return true
+	case on && v > exit:
+		return false
+	}
+	return on
+}
@@ -29,3 +29,97 @@ func TestADX(t *testing.T) {
 		t.Error("final ADX / +DI / -DI is NaN, expected a valid value")
 	}
 }
+
+func TestADXDMI(t *testing.T) {
+	highs := []float64{10, 11, 13, 14, 15, 18, 20, 19, 21, 22, 21, 23}
+	lows := []float64{9, 10, 11, 13, 14, 15, 16, 17, 18, 20, 19, 21}
+	closes := []float64{9, 10, 12, 14, 14, 16, 19, 18, 20, 21, 20, 22}
+
+	adxCalc := indicators.NewADXWithSmoothing(3, 2)
+	dmi, err := adxCalc.CalculateDMI(highs, lows, closes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i := 2; i < len(highs); i++ {
+		sum := dmi.PlusDI[i] + dmi.MinusDI[i]
+		want := math.Abs(dmi.PlusDI[i]-dmi.MinusDI[i]) / sum * 100
+		if math.Abs(dmi.DX[i]-want) > 1e-9 {
+			t.Errorf("index %d: DX got %.4f, want %.4f", i, dmi.DX[i], want)
+		}
+	}
+
+	// DI window 3 and ADX window 2: first ADX at index 3 is the mean of DX[2..3].
+	if want := (dmi.DX[2] + dmi.DX[3]) / 2; math.Abs(dmi.ADX[3]-want) > 1e-9 {
+		t.Errorf("first ADX got %.4f, want %.4f", dmi.ADX[3], want)
+	}
+	for i := 5; i < len(highs); i++ {
+		want := (dmi.ADX[i] + dmi.ADX[i-2]) / 2
+		if math.Abs(dmi.ADXR[i]-want) > 1e-9 {
+			t.Errorf("index %d: ADXR got %.4f, want %.4f", i, dmi.ADXR[i], want)
+		}
+	}
+
+	// Calculate must agree with CalculateDMI.
+	adxVals, plusDI, _, err := adxCalc.Calculate(highs, lows, closes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	last := len(highs) - 1
+	if adxVals[last] != dmi.ADX[last] || plusDI[last] != dmi.PlusDI[last] {
+		t.Error("Calculate and CalculateDMI disagree")
+	}
+	if dmi.PlusDM[last] <= 0 {
+		t.Errorf("expected positive smoothed +DM in an uptrend, got %.4f", dmi.PlusDM[last])
+	}
+}
+
+// NewADX without a separate ADX window must keep producing the values it always has.
+func TestADXDefaultSeeding(t *testing.T) {
+	highs := []float64{10, 11, 13, 14, 15, 18, 20}
+	lows := []float64{9, 10, 11, 13, 14, 15, 16}
+	closes := []float64{9, 10, 12, 14, 14, 16, 19}
+
+	adxVals, _, _, err := indicators.NewADX(3).Calculate(highs, lows, closes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []float64{0, 0, 33.3333333333, 55.5555555556, 70.3703703704, 80.2469135802, 86.8312757202}
+	for i := range want {
+		if math.Abs(adxVals[i]-want[i]) > 1e-9 {
+			t.Errorf("index %d: got %.10f, want %.10f", i, adxVals[i], want[i])
+		}
+	}
+}
+
+func TestADXStream(t *testing.T) {
+	bars := waveBars(80)
+	for _, a := range []*indicators.ADX{indicators.NewADX(14), indicators.NewADXWithSmoothing(14, 10)} {
+		adx, plus, minus, err := a.Calculate(bars.High, bars.Low, bars.Close)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		firstADX := 13
+		if a.ADXWindow != 0 {
+			firstADX += a.ADXWindow - 1
+		}
+		stream, err := a.NewStream()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for i := range bars.Close {
+			ga, gp, gm := stream.Update(bars.High[i], bars.Low[i], bars.Close[i])
+			wa, wp, wm := adx[i], plus[i], minus[i]
+			if i < 13 {
+				wp, wm = math.NaN(), math.NaN()
+			}
+			if i < firstADX {
+				wa = math.NaN()
+			}
+			if !sameFloat(ga, wa) || !sameFloat(gp, wp) || !sameFloat(gm, wm) {
+				t.Errorf("ADX window %d index %d: stream (%v, %v, %v) != batch (%v, %v, %v)",
+					a.ADXWindow, i, ga, gp, gm, wa, wp, wm)
+			}
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestAroon(t *testing.T) {
+	highs := []float64{10, 11, 12, 11, 10, 9}
+	lows := []float64{9, 10, 11, 10, 9, 8}
+
+	aroon := indicators.NewAroon(4)
+	up, down, osc, err := aroon.Calculate(highs, lows)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(up) != len(highs) || len(down) != len(highs) || len(osc) != len(highs) {
+		t.Fatalf("Aroon outputs must match input length")
+	}
+	if !math.IsNaN(up[3]) {
+		t.Errorf("expected NaN before a full window, got %v", up[3])
+	}
+
+	// i=4: window [0..4], highest high at index 2 (2 bars ago), lowest low at index 0 and 4 (use 4).
+	//   up = 100*(4-2)/4 = 50, down = 100*(4-0)/4 = 100
+	// i=5: window [1..5], highest high at index 2 (3 bars ago), lowest low at index 5.
+	//   up = 25, down = 100
+	wantUp := []float64{50, 25}
+	wantDown := []float64{100, 100}
+	for k, i := range []int{4, 5} {
+		if up[i] != wantUp[k] || down[i] != wantDown[k] {
+			t.Errorf("index %d: got up=%.2f down=%.2f, want up=%.2f down=%.2f",
+				i, up[i], down[i], wantUp[k], wantDown[k])
+		}
+		if osc[i] != up[i]-down[i] {
+			t.Errorf("index %d: oscillator %.2f != up-down", i, osc[i])
+		}
+	}
+}
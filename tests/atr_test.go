@@ -30,3 +30,51 @@ func TestATR(t *testing.T) {
 		t.Error("final ATR value should not be NaN")
 	}
 }
+
+func TestATRSimpleSmoothing(t *testing.T) {
+	highs := []float64{10, 11, 13, 14, 15, 17, 17}
+	lows := []float64{9, 9, 11, 13, 14, 15, 16}
+	closes := []float64{9, 10, 12, 14, 14, 16, 17}
+
+	atrCalc := indicators.NewATR(3)
+	atrCalc.Smoothing = indicators.MASimple
+	got, err := atrCalc.Calculate(highs, lows, closes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// True ranges: 1, 2, 3, 2, 1, 3, 1 => SMA(3) from index 2.
+	want := []float64{0, 0, 2, 7.0 / 3, 2, 2, 5.0 / 3}
+	for i, w := range want {
+		if math.Abs(got[i]-w) > 1e-9 {
+			t.Errorf("index %d: got %.5f, want %.5f", i, got[i], w)
+		}
+	}
+}
+
+func TestATRStream(t *testing.T) {
+	highs := []float64{10, 11, 13, 14, 15, 17, 17, 16, 18}
+	lows := []float64{8, 9, 10, 12, 12, 15, 16, 14, 15}
+	closes := []float64{9, 10, 12, 13, 14, 16, 16, 15, 17}
+	atr := indicators.NewATR(3)
+	batch, err := atr.Calculate(highs, lows, closes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stream, err := atr.NewStream()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range closes {
+		got := stream.Update(highs[i], lows[i], closes[i])
+		if i < 2 {
+			if !math.IsNaN(got) {
+				t.Errorf("index %d: got %v during warm-up, want NaN", i, got)
+			}
+			continue
+		}
+		if !sameFloat(got, batch[i]) {
+			t.Errorf("index %d: stream %v != batch %v", i, got, batch[i])
+		}
+	}
+}
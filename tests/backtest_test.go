@@ -0,0 +1,281 @@
+package tests
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/backtest"
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func backtestBars() *indicators.OHLCV {
+	return &indicators.OHLCV{
+		Open:  []float64{10, 11, 12, 13, 14},
+		High:  []float64{11, 12, 13, 14, 15},
+		Low:   []float64{9, 10, 11, 12, 13},
+		Close: []float64{10.5, 11.5, 12.5, 13.5, 14.5},
+	}
+}
+
+func TestBacktestMarketOrders(t *testing.T) {
+	eng := backtest.NewEngine(1000)
+	eng.Commission = backtest.Commission{PerOrder: 1}
+	eng.Slippage = backtest.Slippage{PerUnit: 0.1}
+
+	res, err := eng.Run(backtestBars(), func(ctx *backtest.Context) error {
+		switch ctx.Index() {
+		case 0:
+			_, err := ctx.Buy(10)
+			return err
+		case 2:
+			_, err := ctx.ClosePosition()
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Buy fills at the next open plus slippage: 11.1; the sell at 13 - 0.1 = 12.9.
+	buy := res.Orders[0]
+	if buy.Status != backtest.Filled || buy.FilledBar != 1 || !sameFloat(buy.FillPrice, 11.1) || buy.Commission != 1 {
+		t.Errorf("buy order: %+v", buy)
+	}
+	if !sameFloat(res.Equity[1], 1000-111-1+115) {
+		t.Errorf("equity at bar 1: got %v, want 1003", res.Equity[1])
+	}
+	if res.Position[2] != 10 || res.Position[3] != 0 {
+		t.Errorf("positions: got %v", res.Position)
+	}
+	if len(res.Trades) != 1 {
+		t.Fatalf("got %d trades, want 1", len(res.Trades))
+	}
+	tr := res.Trades[0]
+	if tr.Direction != 1 || tr.EntryBar != 1 || tr.ExitBar != 3 || !sameFloat(tr.EntryPrice, 11.1) ||
+		!sameFloat(tr.ExitPrice, 12.9) || !sameFloat(tr.Commission, 2) || !sameFloat(tr.PnL, 16) ||
+		!sameFloat(tr.Return, 16.0/111) {
+		t.Errorf("trade: %+v", tr)
+	}
+	if !sameFloat(res.FinalEquity(), 1016) {
+		t.Errorf("final equity: got %v, want 1016", res.FinalEquity())
+	}
+}
+
+func TestBacktestLimitAndStopOrders(t *testing.T) {
+	eng := backtest.NewEngine(1000)
+	eng.Slippage = backtest.Slippage{PerUnit: 0.1}
+
+	res, err := eng.Run(backtestBars(), func(ctx *backtest.Context) error {
+		if ctx.Index() != 0 {
+			return nil
+		}
+		ctx.BuyLimit(1, 10.5) // bar 1 trades down to 10: fills at the limit, no slippage
+		ctx.BuyLimit(1, 11.5) // bar 1 opens below the limit: fills at the open
+		ctx.BuyStop(1, 12.5)  // first reached on bar 2: 12.5 plus slippage
+		ctx.SellLimit(1, 100) // never reached
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []struct {
+		status backtest.OrderStatus
+		bar    int
+		price  float64
+	}{
+		{backtest.Filled, 1, 10.5},
+		{backtest.Filled, 1, 11},
+		{backtest.Filled, 2, 12.6},
+		{backtest.Cancelled, -1, 0},
+	}
+	for i, w := range want {
+		o := res.Orders[i]
+		if o.Status != w.status || o.FilledBar != w.bar || !sameFloat(o.FillPrice, w.price) {
+			t.Errorf("order %d: got %v on bar %d at %v, want %v on bar %d at %v",
+				o.ID, o.Status, o.FilledBar, o.FillPrice, w.status, w.bar, w.price)
+		}
+	}
+	if res.Position[4] != 3 {
+		t.Errorf("final position: got %v, want 3", res.Position[4])
+	}
+}
+
+func TestBacktestShorts(t *testing.T) {
+	sellFirst := func(ctx *backtest.Context) error {
+		if ctx.Index() == 0 {
+			_, err := ctx.Sell(1)
+			return err
+		}
+		return nil
+	}
+
+	res, err := backtest.NewEngine(1000).Run(backtestBars(), sellFirst)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Orders[0].Status != backtest.Rejected || res.Position[4] != 0 {
+		t.Errorf("long-only engine: got %v and position %v", res.Orders[0].Status, res.Position[4])
+	}
+
+	eng := backtest.NewEngine(1000)
+	eng.AllowShort = true
+	eng.CloseAtEnd = true
+	res, err = eng.Run(backtestBars(), sellFirst)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res.Trades) != 1 {
+		t.Fatalf("got %d trades, want 1", len(res.Trades))
+	}
+	tr := res.Trades[0]
+	if tr.Direction != -1 || tr.ExitBar != 4 || !sameFloat(tr.PnL, 11-14.5) {
+		t.Errorf("short trade: %+v", tr)
+	}
+	if !sameFloat(res.FinalEquity(), 1000+11-14.5) || res.Position[4] != 0 {
+		t.Errorf("final equity %v position %v", res.FinalEquity(), res.Position[4])
+	}
+
+	// Selling more than is held closes the long and opens a short with the rest.
+	eng = backtest.NewEngine(1000)
+	eng.AllowShort = true
+	res, _ = eng.Run(backtestBars(), func(ctx *backtest.Context) error {
+		switch ctx.Index() {
+		case 0:
+			ctx.Buy(2)
+		case 1:
+			ctx.Sell(3)
+		}
+		return nil
+	})
+	if len(res.Trades) != 1 || res.Trades[0].Quantity != 2 || !sameFloat(res.Trades[0].PnL, 2) {
+		t.Errorf("flip trades: %+v", res.Trades)
+	}
+	if pos := res.Position[4]; pos != -1 {
+		t.Errorf("flip position: got %v, want -1", pos)
+	}
+}
+
+func TestBacktestInsufficientCash(t *testing.T) {
+	eng := backtest.NewEngine(100)
+	eng.Commission = backtest.Commission{PerOrder: 1}
+	res, err := eng.Run(backtestBars(), func(ctx *backtest.Context) error {
+		switch ctx.Index() {
+		case 0:
+			ctx.Buy(10) // 110 at the next open, more than the cash
+		case 1:
+			ctx.Buy(8) // 96 + 1 commission fits
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if o := res.Orders[0]; o.Status != backtest.Rejected || o.Reason != "insufficient cash" {
+		t.Errorf("oversized buy: got %v %q, want rejected", o.Status, o.Reason)
+	}
+	if res.Cash[1] != 100 || res.Position[1] != 0 {
+		t.Errorf("after rejection: cash %v position %v", res.Cash[1], res.Position[1])
+	}
+	if o := res.Orders[1]; o.Status != backtest.Filled || !sameFloat(res.Cash[2], 100-8*12-1) {
+		t.Errorf("affordable buy: got %v, cash %v", o.Status, res.Cash[2])
+	}
+}
+
+func TestBacktestNoLookAhead(t *testing.T) {
+	bars := backtestBars()
+	sma, _ := indicators.NewSMA(2).NewStream()
+	eng := backtest.NewEngine(1000)
+	eng.AddIndicator("sma", backtest.CloseFeed(sma))
+
+	batch, _ := indicators.NewSMA(2).Calculate(bars.Close)
+	_, err := eng.Run(bars, func(ctx *backtest.Context) error {
+		i := ctx.Index()
+		if h := ctx.History(); h.Len() != i+1 || h.Close[i] != bars.Close[i] {
+			t.Errorf("bar %d: history has %d bars", i, h.Len())
+		}
+		if got := len(ctx.Values("sma")); got != i+1 {
+			t.Errorf("bar %d: %d indicator values", i, got)
+		}
+		if i > 0 && !sameFloat(ctx.Value("sma"), batch[i]) {
+			t.Errorf("bar %d: sma %v, want %v", i, ctx.Value("sma"), batch[i])
+		}
+		if i > 1 && !sameFloat(ctx.ValueAt("sma", 1), batch[i-1]) {
+			t.Errorf("bar %d: previous sma %v, want %v", i, ctx.ValueAt("sma", 1), batch[i-1])
+		}
+		if !math.IsNaN(ctx.Value("missing")) {
+			t.Error("expected NaN for an unknown indicator")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestBacktestErrors(t *testing.T) {
+	bars := backtestBars()
+	noop := func(*backtest.Context) error { return nil }
+
+	if _, err := backtest.NewEngine(0).Run(bars, noop); err == nil {
+		t.Error("expected error for zero initial cash")
+	}
+	eng := backtest.NewEngine(1000)
+	eng.AddIndicator("x", func(backtest.Bar) float64 { return 0 })
+	eng.AddIndicator("x", func(backtest.Bar) float64 { return 0 })
+	if _, err := eng.Run(bars, noop); err == nil {
+		t.Error("expected error for a duplicate indicator name")
+	}
+
+	stop := errors.New("stop")
+	_, err := backtest.NewEngine(1000).Run(bars, func(ctx *backtest.Context) error {
+		if _, err := ctx.Buy(0); err == nil {
+			t.Error("expected error for a zero quantity")
+		}
+		if _, err := ctx.BuyLimit(1, 0); err == nil {
+			t.Error("expected error for a limit order without a price")
+		}
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("got %v, want the strategy's error", err)
+	}
+}
+
+func TestBacktestMultiOutputFeeds(t *testing.T) {
+	bars := waveBars(60)
+	macd, _ := indicators.NewMACD(12, 26, 9).NewStream()
+	stoch, _ := indicators.NewStochasticOscillator(14, 3).NewStream()
+	st, _ := indicators.NewSuperTrend(10, 3).NewStream()
+	eng := backtest.NewEngine(1000)
+	eng.AddIndicators([]string{"macd", "signal", "hist"}, backtest.MACDFeed(macd))
+	eng.AddIndicators([]string{"k", "d"}, backtest.StochasticFeed(stoch))
+	eng.AddIndicators([]string{"st", "dir"}, backtest.SuperTrendFeed(st))
+
+	_, wantSignal, _, _ := indicators.NewMACD(12, 26, 9).Calculate(bars.Close)
+	_, wantD, _ := indicators.NewStochasticOscillator(14, 3).Calculate(bars.High, bars.Low, bars.Close)
+	wantST, _ := indicators.NewSuperTrend(10, 3).CalculateSignals(bars.Open, bars.High, bars.Low, bars.Close)
+	_, err := eng.Run(bars, func(ctx *backtest.Context) error {
+		i := ctx.Index()
+		if i >= 33 && !sameFloat(ctx.Value("signal"), wantSignal[i]) {
+			t.Errorf("bar %d: signal %v, want %v", i, ctx.Value("signal"), wantSignal[i])
+		}
+		if !sameFloat(ctx.Value("d"), wantD[i]) {
+			t.Errorf("bar %d: %%D %v, want %v", i, ctx.Value("d"), wantD[i])
+		}
+		if i >= 9 && (!sameFloat(ctx.Value("st"), wantST.Line[i]) || ctx.Value("dir") != float64(wantST.Direction[i])) {
+			t.Errorf("bar %d: supertrend (%v, %v), want (%v, %d)", i, ctx.Value("st"), ctx.Value("dir"), wantST.Line[i], wantST.Direction[i])
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	bad := backtest.NewEngine(1000)
+	bad.AddIndicators([]string{"a", "b"}, func(backtest.Bar) []float64 { return []float64{1} })
+	if _, err := bad.Run(bars, func(*backtest.Context) error { return nil }); err == nil {
+		t.Error("expected error for a feed returning the wrong number of values")
+	}
+}
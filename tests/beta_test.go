@@ -0,0 +1,34 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestBeta(t *testing.T) {
+	// benchmark returns: +10%, -10%, +20%, -5%; asset returns are exactly double
+	bench := []float64{100, 110, 99, 118.8, 112.86}
+	asset := []float64{50, 60, 48, 67.2, 60.48}
+
+	b := indicators.NewBeta(3)
+	got, err := b.Calculate(asset, bench)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if !math.IsNaN(got[i]) {
+			t.Errorf("index %d: expected NaN warm-up, got %.5f", i, got[i])
+		}
+	}
+	for i := 3; i < len(got); i++ {
+		if math.Abs(got[i]-2) > 1e-9 {
+			t.Errorf("index %d: got %.5f, want 2", i, got[i])
+		}
+	}
+
+	if _, err := b.Calculate(asset[:3], bench[:3]); err == nil {
+		t.Error("expected error for insufficient data")
+	}
+}
@@ -2,6 +2,7 @@
 package tests
 
 import (
+	"math"
 	"testing"
 
 	"github.com/copyleftdev/indicator-libs/indicators"
@@ -25,3 +26,64 @@ func TestBollingerBands(t *testing.T) {
 		t.Error("upper band should be >= mid, and lower band <= mid")
 	}
 }
+
+func TestBollingerPercentBAndBandWidth(t *testing.T) {
+	data := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	bb := indicators.NewBollingerBands(5, 2)
+
+	mid, up, low, err := bb.Calculate(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pctB, err := bb.CalculatePercentB(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	width, err := bb.CalculateBandWidth(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !math.IsNaN(pctB[3]) || !math.IsNaN(width[3]) {
+		t.Errorf("expected NaN warm-up values, got %%B=%v width=%v", pctB[3], width[3])
+	}
+	for i := 4; i < len(data); i++ {
+		wantB := (data[i] - low[i]) / (up[i] - low[i])
+		if math.Abs(pctB[i]-wantB) > 1e-9 {
+			t.Errorf("index %d: %%B got %.4f, want %.4f", i, pctB[i], wantB)
+		}
+		// A rising line always closes above the middle band.
+		if pctB[i] <= 0.5 {
+			t.Errorf("index %d: expected %%B above 0.5, got %.4f", i, pctB[i])
+		}
+		wantW := (up[i] - low[i]) / mid[i]
+		if math.Abs(width[i]-wantW) > 1e-9 {
+			t.Errorf("index %d: BandWidth got %.4f, want %.4f", i, width[i], wantW)
+		}
+	}
+}
+
+func TestBollingerBandsStream(t *testing.T) {
+	bars := waveBars(60)
+	bb := indicators.NewBollingerBands(20, 2)
+	mid, up, low, err := bb.Calculate(bars.Close)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stream, err := bb.NewStream()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, p := range bars.Close {
+		gm, gu, gl := stream.Update(p)
+		if i < 19 {
+			if !math.IsNaN(gm) || !math.IsNaN(gu) || !math.IsNaN(gl) {
+				t.Errorf("index %d: got (%v, %v, %v) during warm-up, want NaN", i, gm, gu, gl)
+			}
+			continue
+		}
+		if !sameFloat(gm, mid[i]) || !sameFloat(gu, up[i]) || !sameFloat(gl, low[i]) {
+			t.Errorf("index %d: stream (%v, %v, %v) != batch (%v, %v, %v)", i, gm, gu, gl, mid[i], up[i], low[i])
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+// candles splits {open, high, low, close} rows into slices.
+func candles(rows [][4]float64) (open, high, low, close []float64) {
+	for _, r := range rows {
+		open = append(open, r[0])
+		high = append(high, r[1])
+		low = append(low, r[2])
+		close = append(close, r[3])
+	}
+	return
+}
+
+func TestCandlestickPatterns(t *testing.T) {
+	cp := indicators.NewCandlestickPatterns()
+	cp.Range = indicators.CandleRangeAverage
+	cp.RangeWindow = 3
+	cp.TrendWindow = 2
+
+	// every prefix bar has a range of 2, so the reference range is 2 throughout
+	down := [][4]float64{{13.5, 14, 12, 12.5}, {12.5, 13, 11, 11.5}, {11.5, 12, 10, 10.5}, {10.5, 11, 9, 9.5}}
+	up := [][4]float64{{9.5, 11, 9, 10.5}, {10.5, 12, 10, 11.5}, {11.5, 13, 11, 12.5}, {12.5, 14, 12, 13.5}}
+
+	cases := []struct {
+		name    string
+		pattern indicators.CandlePattern
+		rows    [][4]float64
+		want    int
+	}{
+		{"doji", indicators.PatternDoji, append(up, [4]float64{13.5, 14, 13, 13.6}), 100},
+		{"hammer", indicators.PatternHammer, append(down, [4]float64{9.0, 9.4, 8.1, 9.3}), 100},
+		{"hammer needs a downtrend", indicators.PatternHammer, append(up, [4]float64{13.0, 13.4, 12.1, 13.3}), 0},
+		{"hanging man", indicators.PatternHangingMan, append(up, [4]float64{13.0, 13.4, 12.1, 13.3}), -100},
+		{"inverted hammer", indicators.PatternInvertedHammer, append(down, [4]float64{9.0, 10.0, 8.9, 9.3}), 100},
+		{"shooting star", indicators.PatternShootingStar, append(up, [4]float64{13.3, 14.3, 12.9, 13.0}), -100},
+		{"bullish engulfing", indicators.PatternEngulfing, append(down, [4]float64{9.4, 10.8, 8.8, 10.6}), 100},
+		{"bearish engulfing", indicators.PatternEngulfing, append(up, [4]float64{13.6, 14.2, 12.2, 12.4}), -100},
+		{"bullish harami", indicators.PatternHarami, append(down, [4]float64{11.5, 11.8, 9.8, 10}, [4]float64{10.4, 10.9, 10.2, 10.6}), 100},
+		{"piercing", indicators.PatternPiercing, append(down, [4]float64{11, 11.2, 9.0, 9.2}, [4]float64{8.8, 10.5, 8.7, 10.3}), 100},
+		{"dark cloud cover", indicators.PatternDarkCloudCover, append(up, [4]float64{12, 14.0, 11.9, 13.8}, [4]float64{14.2, 14.3, 12.5, 12.8}), -100},
+		{"morning star", indicators.PatternMorningStar,
+			append(down, [4]float64{9.5, 9.6, 7.9, 8.0}, [4]float64{7.7, 7.8, 7.4, 7.6}, [4]float64{7.8, 9.3, 7.7, 9.2}), 100},
+		{"evening star", indicators.PatternEveningStar,
+			append(up, [4]float64{13.5, 15.1, 13.4, 15.0}, [4]float64{15.3, 15.6, 15.2, 15.4}, [4]float64{15.2, 15.3, 13.7, 13.8}), -100},
+		{"three white soldiers", indicators.PatternThreeWhiteSoldiers,
+			append(down, [4]float64{9.4, 10.95, 9.3, 10.9}, [4]float64{10.2, 12.45, 10.0, 12.4}, [4]float64{11.5, 13.95, 11.3, 13.9}), 100},
+		{"three black crows", indicators.PatternThreeBlackCrows,
+			append(up, [4]float64{13.5, 13.55, 11.9, 12.0}, [4]float64{12.8, 12.85, 10.55, 10.6}, [4]float64{11.5, 11.55, 9.05, 9.1}), -100},
+	}
+	for _, tc := range cases {
+		o, h, l, c := candles(tc.rows)
+		got, err := cp.Detect(tc.pattern, o, h, l, c)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if last := got[len(got)-1]; last != tc.want {
+			t.Errorf("%s: got %d, want %d", tc.name, last, tc.want)
+		}
+	}
+
+	// the scan sums every pattern: a bullish engulfing bar is net bullish
+	o, h, l, c := candles(append(down, [4]float64{9.4, 10.8, 8.8, 10.6}))
+	res, err := cp.Calculate(o, h, l, c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Patterns[indicators.PatternEngulfing][4] != 100 || res.Score[4] <= 0 {
+		t.Errorf("scan: engulfing %d, score %d", res.Patterns[indicators.PatternEngulfing][4], res.Score[4])
+	}
+	if indicators.PatternMorningStar.String() != "morning star" {
+		t.Errorf("unexpected pattern name %q", indicators.PatternMorningStar.String())
+	}
+
+	if _, err := cp.Detect(indicators.PatternDoji, o[:3], h[:3], l[:3], c[:3]); err == nil {
+		t.Error("expected error for insufficient data")
+	}
+}
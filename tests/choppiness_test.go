@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestChoppinessIndex(t *testing.T) {
+	// Trending: ranges do not overlap, so sum(TR) equals the window range => CHOP = 0.
+	trendHighs := []float64{1, 2, 3, 4, 5}
+	trendLows := []float64{0, 1, 2, 3, 4}
+	trendCloses := []float64{1, 2, 3, 4, 5}
+
+	chop := indicators.NewChoppinessIndex(4)
+	trend, err := chop.Calculate(trendHighs, trendLows, trendCloses)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !math.IsNaN(trend[2]) {
+		t.Errorf("expected NaN before a full window, got %v", trend[2])
+	}
+	for i := 3; i < len(trend); i++ {
+		if math.Abs(trend[i]) > 1e-9 {
+			t.Errorf("index %d: trending CHOP got %.4f, want 0", i, trend[i])
+		}
+	}
+
+	// Sideways: identical bars overlap fully, sum(TR) = period * range => CHOP = 100.
+	flatHighs := []float64{2, 2, 2, 2, 2}
+	flatLows := []float64{1, 1, 1, 1, 1}
+	flatCloses := []float64{1.5, 1.5, 1.5, 1.5, 1.5}
+	side, err := chop.Calculate(flatHighs, flatLows, flatCloses)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 3; i < len(side); i++ {
+		if math.Abs(side[i]-100) > 1e-9 {
+			t.Errorf("index %d: sideways CHOP got %.4f, want 100", i, side[i])
+		}
+	}
+}
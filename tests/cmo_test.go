@@ -0,0 +1,31 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestCMO(t *testing.T) {
+	data := []float64{10, 11, 10.5, 12, 12}
+	cmo := indicators.NewCMO(3)
+	got, err := cmo.Calculate(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// i=3: changes +1, -0.5, +1.5 => up=2.5, down=0.5 => 100*2/3 = 66.667
+	// i=4: changes -0.5, +1.5, 0  => up=1.5, down=0.5 => 100*1/2 = 50
+	if math.Abs(got[3]-200.0/3.0) > 1e-9 {
+		t.Errorf("index 3: got %.5f, want %.5f", got[3], 200.0/3.0)
+	}
+	if math.Abs(got[4]-50) > 1e-9 {
+		t.Errorf("index 4: got %.5f, want 50", got[4])
+	}
+	for i, v := range got[3:] {
+		if v < -100 || v > 100 {
+			t.Errorf("index %d: CMO %.2f out of [-100, 100]", i+3, v)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestConnorsRSI(t *testing.T) {
+	prices := []float64{10, 10.5, 10.2, 10.8, 11, 11.3, 11.1, 10.7, 10.4, 10.9, 11.5, 11.2}
+
+	crsi := indicators.NewConnorsRSI(3, 2, 5)
+	got, err := crsi.Calculate(prices)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != len(prices) {
+		t.Fatalf("Connors RSI output must match input length")
+	}
+
+	// All components are available from index rankPeriod+1 = 6.
+	if !math.IsNaN(got[5]) || math.IsNaN(got[6]) {
+		t.Errorf("unexpected warm-up: got[5]=%v got[6]=%v", got[5], got[6])
+	}
+
+	// Recompute the last value from its components.
+	last := len(prices) - 1
+	priceRSI, _ := indicators.NewRSI(3).Calculate(prices)
+	streak := []float64{0, 1, -1, 1, 2, 3, -1, -2, -3, 1, 2, -1}
+	streakRSI, _ := indicators.NewRSI(2).Calculate(streak)
+	roc, _ := indicators.NewROC(1).Calculate(prices)
+	below := 0
+	for j := last - 5; j < last; j++ {
+		if roc[j] < roc[last] {
+			below++
+		}
+	}
+	want := (priceRSI[last] + streakRSI[last] + 100*float64(below)/5) / 3
+	if math.Abs(got[last]-want) > 1e-9 {
+		t.Errorf("final Connors RSI got %.4f, want %.4f", got[last], want)
+	}
+	for i := 6; i < len(got); i++ {
+		if got[i] < 0 || got[i] > 100 {
+			t.Errorf("index %d: Connors RSI %.2f out of [0, 100]", i, got[i])
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestRollingCorrelation(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5}
+	y := []float64{2, 4, 6, 8, 10}
+	inv := []float64{5, 4, 3, 2, 1}
+
+	pearson := indicators.NewRollingCorrelation(3, indicators.CorrelationPearson)
+	got, err := pearson.Calculate(x, y)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !math.IsNaN(got[0]) || !math.IsNaN(got[1]) {
+		t.Error("expected NaN warm-up values")
+	}
+	for i := 2; i < len(x); i++ {
+		if math.Abs(got[i]-1) > 1e-9 {
+			t.Errorf("index %d: got %.5f, want 1", i, got[i])
+		}
+	}
+	got, _ = pearson.Calculate(x, inv)
+	if math.Abs(got[4]+1) > 1e-9 {
+		t.Errorf("inverse series: got %.5f, want -1", got[4])
+	}
+
+	// Monotonic but non-linear: Spearman is exactly 1, Pearson is below 1
+	sq := []float64{1, 4, 9, 100, 1000}
+	sp, err := indicators.NewRollingCorrelation(5, indicators.CorrelationSpearman).Calculate(x, sq)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(sp[4]-1) > 1e-9 {
+		t.Errorf("spearman: got %.5f, want 1", sp[4])
+	}
+	pe, _ := indicators.NewRollingCorrelation(5, indicators.CorrelationPearson).Calculate(x, sq)
+	if pe[4] >= 0.99 {
+		t.Errorf("pearson on convex series should be < 0.99, got %.5f", pe[4])
+	}
+
+	// Ties share their average rank: ranks {1.5, 1.5, 3} vs {1, 2, 3} => sqrt(3)/2
+	sp, _ = indicators.NewRollingCorrelation(3, indicators.CorrelationSpearman).Calculate([]float64{1, 1, 2}, []float64{1, 2, 3})
+	if math.Abs(sp[2]-math.Sqrt(3)/2) > 1e-9 {
+		t.Errorf("spearman with ties: got %.5f, want %.5f", sp[2], math.Sqrt(3)/2)
+	}
+
+	// constant window => NaN
+	got, _ = pearson.Calculate([]float64{1, 1, 1}, []float64{1, 2, 3})
+	if !math.IsNaN(got[2]) {
+		t.Errorf("expected NaN for constant series, got %.5f", got[2])
+	}
+
+	if _, err := pearson.Calculate(x, y[:4]); err == nil {
+		t.Error("expected error for mismatched lengths")
+	}
+}
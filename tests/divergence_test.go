@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestDivergenceRegular(t *testing.T) {
+	prices := []float64{10, 14, 11, 15, 12, 9, 11, 8, 10}
+	osc := []float64{50, 70, 40, 60, 55, 30, 45, 35, 50}
+
+	det := indicators.NewDivergenceDetector(1, 1, 1, 0)
+	divs, err := det.Calculate(prices, prices, osc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(divs) != 2 {
+		t.Fatalf("got %d divergences (%+v), want 2", len(divs), divs)
+	}
+
+	// swing highs 1 -> 3: price 14 -> 15, RSI 70 -> 60
+	bear := divs[0]
+	if bear.Kind != indicators.DivergenceRegularBearish || bear.StartIndex != 1 || bear.EndIndex != 3 || bear.ConfirmedIndex != 4 {
+		t.Errorf("bearish: %+v", bear)
+	}
+	mean := (70.0 + 40 + 60) / 3
+	sd := math.Sqrt(((70-mean)*(70-mean) + (40-mean)*(40-mean) + (60-mean)*(60-mean)) / 2)
+	if math.Abs(bear.Strength-10/sd) > 1e-9 {
+		t.Errorf("strength: got %.5f, want %.5f", bear.Strength, 10/sd)
+	}
+
+	// swing lows 5 -> 7: price 9 -> 8, RSI 30 -> 35 (lows 2 -> 5 fall together: no divergence)
+	bull := divs[1]
+	if bull.Kind != indicators.DivergenceRegularBullish || bull.StartIndex != 5 || bull.EndIndex != 7 || bull.ConfirmedIndex != 8 {
+		t.Errorf("bullish: %+v", bull)
+	}
+	if !bull.Kind.Bullish() || bear.Kind.Bullish() {
+		t.Error("unexpected Bullish() result")
+	}
+
+	// MaxBars excludes swings that are too far apart
+	det.MaxBars = 1
+	if divs, _ := det.Calculate(prices, prices, osc); len(divs) != 0 {
+		t.Errorf("expected no divergences with MaxBars 1, got %+v", divs)
+	}
+}
+
+func TestDivergenceHidden(t *testing.T) {
+	prices := []float64{10, 8, 12, 9, 13}
+	osc := []float64{50, 40, 60, 35, 65}
+
+	det := indicators.NewDivergenceDetector(1, 1, 1, 0)
+	if divs, _ := det.Calculate(prices, prices, osc); len(divs) != 0 {
+		t.Errorf("hidden divergences should be off by default, got %+v", divs)
+	}
+	det.Hidden = true
+	divs, err := det.Calculate(prices, prices, osc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// higher low in price (8 -> 9), lower low in the oscillator (40 -> 35)
+	if len(divs) != 1 || divs[0].Kind != indicators.DivergenceHiddenBullish || divs[0].EndIndex != 3 {
+		t.Errorf("got %+v, want one hidden bullish divergence ending at 3", divs)
+	}
+
+	if _, err := det.Calculate(prices, prices, osc[:3]); err == nil {
+		t.Error("expected error for mismatched lengths")
+	}
+}
@@ -0,0 +1,38 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestDonchianChannels(t *testing.T) {
+	highs := []float64{10, 12, 11, 13, 12, 14}
+	lows := []float64{9, 10, 9.5, 11, 10.5, 12}
+
+	dc := indicators.NewDonchianChannels(3)
+	mid, up, low, err := dc.Calculate(highs, lows)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mid) != len(highs) || len(up) != len(highs) || len(low) != len(highs) {
+		t.Fatalf("output slice lengths must match input")
+	}
+	if !math.IsNaN(up[1]) {
+		t.Errorf("expected NaN before a full window, got %v", up[1])
+	}
+
+	wantUp := []float64{12, 13, 13, 14}
+	wantLow := []float64{9, 9.5, 9.5, 10.5}
+	for k := range wantUp {
+		i := k + 2
+		if up[i] != wantUp[k] || low[i] != wantLow[k] {
+			t.Errorf("index %d: got up=%.2f low=%.2f, want up=%.2f low=%.2f",
+				i, up[i], low[i], wantUp[k], wantLow[k])
+		}
+		if mid[i] != (wantUp[k]+wantLow[k])/2 {
+			t.Errorf("index %d: middle %.2f is not the channel midpoint", i, mid[i])
+		}
+	}
+}
@@ -35,3 +35,28 @@ func TestEMA(t *testing.T) {
 		}
 	}
 }
+
+func TestEMAStream(t *testing.T) {
+	data := []float64{3, 5, 4, 8, 9, 7, 6, 10}
+	ema := indicators.NewEMA(3)
+	batch, err := ema.Calculate(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stream, err := ema.NewStream()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, p := range data {
+		got := stream.Update(p)
+		if i < 2 {
+			if !math.IsNaN(got) {
+				t.Errorf("index %d: got %v during warm-up, want NaN", i, got)
+			}
+			continue
+		}
+		if !sameFloat(got, batch[i]) {
+			t.Errorf("index %d: stream %v != batch %v", i, got, batch[i])
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestEnvelope(t *testing.T) {
+	data := []float64{10, 11, 12, 13, 14, 15}
+
+	env := indicators.NewEnvelope(indicators.NewSMA(3), 10)
+	mid, up, low, err := env.Calculate(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sma, _ := indicators.NewSMA(3).Calculate(data)
+	for i := range data {
+		if mid[i] != sma[i] {
+			t.Errorf("index %d: middle %.4f != SMA %.4f", i, mid[i], sma[i])
+		}
+		if math.Abs(up[i]-sma[i]*1.1) > 1e-9 || math.Abs(low[i]-sma[i]*0.9) > 1e-9 {
+			t.Errorf("index %d: bands got up=%.4f low=%.4f", i, up[i], low[i])
+		}
+	}
+
+	// Any Indicator works as the middle line.
+	if _, _, _, err := indicators.NewEnvelope(indicators.NewEMA(3), 2.5).Calculate(data); err != nil {
+		t.Errorf("unexpected error with EMA envelope: %v", err)
+	}
+	if _, _, _, err := indicators.NewEnvelope(nil, 2.5).Calculate(data); err == nil {
+		t.Error("expected error when no moving average is provided")
+	}
+}
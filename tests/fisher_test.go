@@ -0,0 +1,47 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestFisherTransform(t *testing.T) {
+	var highs, lows []float64
+	for i := 0; i < 40; i++ {
+		mid := 100 + 5*math.Sin(2*math.Pi*float64(i)/20)
+		highs = append(highs, mid+0.5)
+		lows = append(lows, mid-0.5)
+	}
+
+	fisher, signal, err := indicators.NewFisherTransform(10).Calculate(highs, lows)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !math.IsNaN(fisher[8]) || math.IsNaN(fisher[9]) || !math.IsNaN(signal[9]) {
+		t.Errorf("unexpected warm-up: fisher[8]=%v fisher[9]=%v signal[9]=%v", fisher[8], fisher[9], signal[9])
+	}
+	for i := 10; i < len(fisher); i++ {
+		if signal[i] != fisher[i-1] {
+			t.Errorf("index %d: signal must equal the previous Fisher value", i)
+		}
+		if math.IsInf(fisher[i], 0) || math.IsNaN(fisher[i]) {
+			t.Errorf("index %d: Fisher is not finite", i)
+		}
+	}
+}
+
+func TestInverseFisher(t *testing.T) {
+	rsi := []float64{50, 80, 20, 100}
+	got, err := indicators.NewInverseFisher(50, 0.1).Calculate(rsi)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []float64{0, math.Tanh(3), math.Tanh(-3), math.Tanh(5)}
+	for i, w := range want {
+		if math.Abs(got[i]-w) > 1e-12 {
+			t.Errorf("index %d: got %.6f, want %.6f", i, got[i], w)
+		}
+	}
+}
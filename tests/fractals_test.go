@@ -0,0 +1,35 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestFractals(t *testing.T) {
+	highs := []float64{1, 2, 5, 3, 2, 4, 6, 4, 3}
+	lows := []float64{0, 1, 4, 2, 1, 3, 5, 3, 2}
+
+	res, err := indicators.NewFractals().Calculate(highs, lows)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res.Up) != 2 || res.Up[0] != 2 || res.Up[1] != 6 {
+		t.Errorf("up fractals: got %v, want [2 6]", res.Up)
+	}
+	if len(res.Down) != 1 || res.Down[0] != 4 {
+		t.Errorf("down fractals: got %v, want [4]", res.Down)
+	}
+	// fractals are only known two bars later
+	for i := range highs {
+		wantUp := i == 4 || i == 8
+		wantDown := i == 6
+		if res.UpConfirmed[i] != wantUp || res.DownConfirmed[i] != wantDown {
+			t.Errorf("index %d: confirmed up %v down %v", i, res.UpConfirmed[i], res.DownConfirmed[i])
+		}
+	}
+
+	if _, err := indicators.NewFractals().Calculate(highs[:4], lows[:4]); err == nil {
+		t.Error("expected error for insufficient data")
+	}
+}
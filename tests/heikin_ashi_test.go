@@ -0,0 +1,48 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestHeikinAshi(t *testing.T) {
+	bars := &indicators.OHLCV{
+		Open:   []float64{10, 11, 12},
+		High:   []float64{12, 13, 13},
+		Low:    []float64{9, 10, 10},
+		Close:  []float64{11, 12, 10.5},
+		Volume: []float64{100, 200, 300},
+	}
+	ha, err := indicators.NewHeikinAshi().Calculate(bars)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// bar 0: close = 42/4 = 10.5, open = 10.5, high = 12, low = 9
+	// bar 1: close = 46/4 = 11.5, open = (10.5+10.5)/2 = 10.5, high = 13, low = 10
+	// bar 2: close = 45.5/4 = 11.375, open = (10.5+11.5)/2 = 11, high = 13, low = 10
+	want := [][4]float64{
+		{10.5, 12, 9, 10.5},
+		{10.5, 13, 10, 11.5},
+		{11, 13, 10, 11.375},
+	}
+	for i, w := range want {
+		got := [4]float64{ha.Open[i], ha.High[i], ha.Low[i], ha.Close[i]}
+		for j := range w {
+			if math.Abs(got[j]-w[j]) > 1e-9 {
+				t.Errorf("bar %d: got %v, want %v", i, got, w)
+				break
+			}
+		}
+	}
+	if ha.Volume[2] != 300 {
+		t.Errorf("volume not carried over: %v", ha.Volume)
+	}
+
+	bars.Open = nil
+	if _, err := indicators.NewHeikinAshi().Calculate(bars); err == nil {
+		t.Error("expected error when open prices are missing")
+	}
+}
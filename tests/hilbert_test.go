@@ -0,0 +1,37 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestHilbertDominantCycle(t *testing.T) {
+	// A pure 20-bar sine wave should be measured as a ~20-bar cycle.
+	prices := make([]float64, 200)
+	for i := range prices {
+		prices[i] = 100 + 5*math.Sin(2*math.Pi*float64(i)/20)
+	}
+
+	period, phase, err := indicators.NewHilbertDominantCycle().Calculate(prices)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(period) != len(prices) || len(phase) != len(prices) {
+		t.Fatalf("output slices must match input length")
+	}
+	if !math.IsNaN(period[0]) || !math.IsNaN(phase[0]) {
+		t.Error("expected NaN during warm-up")
+	}
+
+	last := len(prices) - 1
+	if math.Abs(period[last]-20) > 2 {
+		t.Errorf("expected dominant cycle near 20 bars, got %.2f", period[last])
+	}
+	for i := 100; i < len(phase); i++ {
+		if phase[i] < -45 || phase[i] > 315 {
+			t.Errorf("index %d: phase %.2f outside [-45, 315]", i, phase[i])
+		}
+	}
+}
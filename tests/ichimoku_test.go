@@ -39,3 +39,60 @@ func TestIchimoku(t *testing.T) {
 		t.Log("SpanA might be NaN due to forward shift.")
 	}
 }
+
+func TestIchimokuExtendedCloudAndSignals(t *testing.T) {
+	// Rally followed by a sell-off so the Tenkan/Kijun cross and the cloud twists.
+	highs := []float64{10, 11, 12, 13, 14, 15, 16, 17, 16, 15, 14, 13, 12, 11, 10, 9}
+	lows := []float64{9, 10, 11, 12, 13, 14, 15, 16, 15, 14, 13, 12, 11, 10, 9, 8}
+	closes := []float64{9.5, 10.5, 11.5, 12.5, 13.5, 14.5, 15.5, 16.5, 15.5, 14.5, 13.5, 12.5, 11.5, 10.5, 9.5, 8.5}
+
+	ichimoku := indicators.NewIchimoku(2, 4, 6, 3)
+	ichimoku.ExtendCloud = true
+	tenkan, kijun, spanA, spanB, _, err := ichimoku.Calculate(highs, lows, closes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tenkan) != len(highs) {
+		t.Errorf("tenkan length got %d, want %d", len(tenkan), len(highs))
+	}
+	if len(spanA) != len(highs)+3 || len(spanB) != len(highs)+3 {
+		t.Fatalf("extended spans must have len(input)+Shift values, got %d and %d", len(spanA), len(spanB))
+	}
+	// The last projected value comes from the last input bar.
+	last := len(highs) - 1
+	if want := (tenkan[last] + kijun[last]) / 2; spanA[last+3] != want {
+		t.Errorf("projected Span A got %.4f, want %.4f", spanA[last+3], want)
+	}
+
+	sig, err := ichimoku.Signals(highs, lows, closes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sig.PriceVsCloud) != len(highs) || len(sig.TKCross) != len(highs) {
+		t.Errorf("price and TK signals must match input length")
+	}
+	if len(sig.CloudColor) != len(highs)+3 || len(sig.CloudTwist) != len(highs)+3 {
+		t.Errorf("cloud signals must cover the projected cloud")
+	}
+
+	var bearishCross, twist bool
+	for _, c := range sig.TKCross {
+		if c == -1 {
+			bearishCross = true
+		}
+	}
+	for _, tw := range sig.CloudTwist {
+		if tw {
+			twist = true
+		}
+	}
+	if !bearishCross {
+		t.Error("expected a bearish Tenkan/Kijun cross during the sell-off")
+	}
+	if !twist {
+		t.Error("expected the cloud to twist after the reversal")
+	}
+	if sig.PriceVsCloud[last] != -1 {
+		t.Errorf("expected price below the cloud at the end, got %d", sig.PriceVsCloud[last])
+	}
+}
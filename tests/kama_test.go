@@ -39,3 +39,52 @@ func TestKAMA(t *testing.T) {
 	// if you have one. For demonstration, we'll just log it.
 	t.Logf("Final KAMA value: %.5f", kamaVals[len(kamaVals)-1])
 }
+
+func TestKAMAAdaptive(t *testing.T) {
+	prices := make([]float64, 150)
+	for i := range prices {
+		prices[i] = 100 + 5*math.Sin(2*math.Pi*float64(i)/20) + 0.1*float64(i)
+	}
+	kama := indicators.NewKAMA(10, 2, 30)
+
+	// A constant period reproduces Calculate.
+	constant := make([]float64, len(prices))
+	for i := range constant {
+		constant[i] = 10
+	}
+	want, err := kama.Calculate(prices)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := kama.CalculateAdaptive(prices, constant)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range want {
+		if !sameFloat(got[i], want[i]) {
+			t.Fatalf("index %d: adaptive %v != fixed %v", i, got[i], want[i])
+		}
+	}
+
+	// Periods from the dominant cycle: NaN until the cycle is measured, then finite.
+	cycle, _, err := indicators.NewHilbertDominantCycle().Calculate(prices)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err = kama.CalculateAdaptive(prices, cycle)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range got {
+		if math.IsNaN(cycle[i]) != math.IsNaN(got[i]) {
+			t.Fatalf("index %d: cycle %v but KAMA %v", i, cycle[i], got[i])
+		}
+	}
+	if last := got[len(got)-1]; math.Abs(last-prices[len(prices)-1]) > 10 {
+		t.Errorf("adaptive KAMA %v far from price %v", last, prices[len(prices)-1])
+	}
+
+	if _, err := kama.CalculateAdaptive(prices, constant[:10]); err == nil {
+		t.Error("expected error for mismatched lengths")
+	}
+}
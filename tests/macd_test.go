@@ -2,6 +2,7 @@
 package tests
 
 import (
+	"math"
 	"testing"
 
 	"github.com/copyleftdev/indicator-libs/indicators"
@@ -25,3 +26,29 @@ func TestMACD(t *testing.T) {
 		t.Log("MACD final might be 0, consider a more precise test if needed.")
 	}
 }
+
+func TestMACDStream(t *testing.T) {
+	bars := waveBars(80)
+	m := indicators.NewMACD(12, 26, 9)
+	macd, signal, hist, err := m.Calculate(bars.Close)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stream, err := m.NewStream()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, p := range bars.Close {
+		gm, gs, gh := stream.Update(p)
+		wm, ws, wh := macd[i], signal[i], hist[i]
+		if i < 25 {
+			wm = math.NaN()
+		}
+		if i < 33 {
+			ws, wh = math.NaN(), math.NaN()
+		}
+		if !sameFloat(gm, wm) || !sameFloat(gs, ws) || !sameFloat(gh, wh) {
+			t.Errorf("index %d: stream (%v, %v, %v) != batch (%v, %v, %v)", i, gm, gs, gh, wm, ws, wh)
+		}
+	}
+}
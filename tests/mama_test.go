@@ -0,0 +1,36 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestMAMA(t *testing.T) {
+	prices := make([]float64, 120)
+	for i := range prices {
+		prices[i] = 100 + 0.5*float64(i) + 2*math.Sin(2*math.Pi*float64(i)/15)
+	}
+
+	mama, fama, err := indicators.NewMAMA(0.5, 0.05).Calculate(prices)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mama) != len(prices) || len(fama) != len(prices) {
+		t.Fatalf("output slices must match input length")
+	}
+	if !math.IsNaN(mama[0]) || !math.IsNaN(fama[0]) {
+		t.Error("expected NaN during warm-up")
+	}
+
+	// In a rising market MAMA lags price and FAMA lags MAMA.
+	last := len(prices) - 1
+	if !(fama[last] < mama[last] && mama[last] < prices[last]+2) {
+		t.Errorf("unexpected ordering: price=%.2f mama=%.2f fama=%.2f", prices[last], mama[last], fama[last])
+	}
+
+	if _, _, err := indicators.NewMAMA(0.05, 0.5).Calculate(prices); err == nil {
+		t.Error("expected error when slowLimit exceeds fastLimit")
+	}
+}
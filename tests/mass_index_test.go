@@ -0,0 +1,31 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestMassIndex(t *testing.T) {
+	// A constant range makes Single == Double, so each ratio is 1 and Mass == sumPeriod.
+	highs := []float64{11, 12, 13, 14, 15, 16, 17, 18}
+	lows := []float64{10, 11, 12, 13, 14, 15, 16, 17}
+
+	mi := indicators.NewMassIndex(3, 5)
+	mass, err := mi.Calculate(highs, lows)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mass) != len(highs) {
+		t.Fatalf("Mass Index output must match input length")
+	}
+	if !math.IsNaN(mass[3]) {
+		t.Errorf("expected NaN before a full window, got %v", mass[3])
+	}
+	for i := 4; i < len(mass); i++ {
+		if math.Abs(mass[i]-5) > 1e-9 {
+			t.Errorf("index %d: got %.4f, want 5", i, mass[i])
+		}
+	}
+}
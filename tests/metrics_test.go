@@ -0,0 +1,121 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/backtest"
+)
+
+func TestReturnRatios(t *testing.T) {
+	if got := backtest.Returns([]float64{100, 110, 99}); len(got) != 2 || !sameFloat(got[0], 0.1) || !sameFloat(got[1], -0.1) {
+		t.Errorf("Returns: got %v", got)
+	}
+	// Mean 0.02, sample deviation 0.01*sqrt(2), four periods a year.
+	if got := backtest.Sharpe([]float64{0.01, 0.03}, 0, 4); !sameFloat(got, 0.02/(0.01*math.Sqrt2)*2) {
+		t.Errorf("Sharpe: got %v", got)
+	}
+	// Mean 0.005, downside deviation sqrt((0.01^2 + 0.02^2) / 4).
+	if got := backtest.Sortino([]float64{0.02, -0.01, 0.03, -0.02}, 0, 1); !sameFloat(got, 1/math.Sqrt(5)) {
+		t.Errorf("Sortino: got %v", got)
+	}
+	if got := backtest.Sharpe([]float64{0.01, 0.01, 0.01}, 0, 252); !math.IsNaN(got) {
+		t.Errorf("Sharpe of constant returns: got %v, want NaN", got)
+	}
+	if got := backtest.Sortino([]float64{0.01, 0.02}, 0, 252); !math.IsNaN(got) {
+		t.Errorf("Sortino without losses: got %v, want NaN", got)
+	}
+}
+
+func TestGrowthAndDrawdown(t *testing.T) {
+	if got := backtest.CAGR([]float64{100, 110, 121}, 1); !sameFloat(got, 0.1) {
+		t.Errorf("CAGR: got %v, want 0.1", got)
+	}
+	if got := backtest.Calmar([]float64{100, 110, 99, 121}, 3); !sameFloat(got, 2.1) {
+		t.Errorf("Calmar: got %v, want 2.1", got)
+	}
+
+	equity := []float64{100, 110, 99, 105, 111, 108}
+	dd := backtest.MaxDrawdown(equity)
+	if !sameFloat(dd.Depth, 0.1) || dd.Peak != 1 || dd.Trough != 2 || dd.Recovery != 4 {
+		t.Errorf("MaxDrawdown: got %+v", dd)
+	}
+	if got := backtest.MaxDrawdownDuration(equity); got != 2 {
+		t.Errorf("MaxDrawdownDuration: got %d, want 2", got)
+	}
+	series := backtest.DrawdownSeries(equity)
+	if !sameFloat(series[3], 5.0/110) || series[4] != 0 {
+		t.Errorf("DrawdownSeries: got %v", series)
+	}
+	if dd := backtest.MaxDrawdown([]float64{100, 90, 95}); dd.Recovery != -1 {
+		t.Errorf("unrecovered drawdown: got %+v", dd)
+	}
+
+	rolling := backtest.RollingMaxDrawdown(equity, 2)
+	want := []float64{math.NaN(), math.NaN(), 0.1, 0.1, 0, 3.0 / 111}
+	for i := range want {
+		if !sameFloat(rolling[i], want[i]) {
+			t.Errorf("RollingMaxDrawdown index %d: got %v, want %v", i, rolling[i], want[i])
+		}
+	}
+	if got := backtest.RollingCAGR([]float64{100, 110, 121, 121}, 2, 1); !sameFloat(got[2], 0.1) || !math.IsNaN(got[1]) {
+		t.Errorf("RollingCAGR: got %v", got)
+	}
+}
+
+func TestTradeStats(t *testing.T) {
+	var trades []backtest.Trade
+	for _, pnl := range []float64{10, -5, 0, 20, -5} {
+		trades = append(trades, backtest.Trade{PnL: pnl})
+	}
+	s := backtest.NewTradeStats(trades)
+	if s.Count != 5 || s.Wins != 2 || s.Losses != 2 || !sameFloat(s.WinRate, 0.4) ||
+		!sameFloat(s.ProfitFactor, 3) || !sameFloat(s.AvgWin, 15) || !sameFloat(s.AvgLoss, 5) ||
+		!sameFloat(s.Expectancy, 4) {
+		t.Errorf("got %+v", s)
+	}
+
+	rolling := backtest.RollingTradeStats(trades, 2)
+	if rolling[0].Count != 1 || rolling[4].Count != 2 || !sameFloat(rolling[4].Expectancy, 7.5) {
+		t.Errorf("RollingTradeStats: got %+v", rolling)
+	}
+	if got := backtest.RollingTradeStats(trades, 0); got != nil {
+		t.Errorf("RollingTradeStats with n=0: got %+v, want nil", got)
+	}
+	if got := backtest.RollingTradeStats(trades, -1); got != nil {
+		t.Errorf("RollingTradeStats with n=-1: got %+v, want nil", got)
+	}
+	if s := backtest.NewTradeStats(trades[:1]); !math.IsInf(s.ProfitFactor, 1) {
+		t.Errorf("profit factor without losses: got %v", s.ProfitFactor)
+	}
+
+	if got := backtest.Exposure([]float64{0, 1, 1, 0}); got != 0.5 {
+		t.Errorf("Exposure: got %v, want 0.5", got)
+	}
+	if got := backtest.RollingExposure([]float64{0, 1, 1, 0}, 2); !math.IsNaN(got[0]) || got[2] != 1 || got[3] != 0.5 {
+		t.Errorf("RollingExposure: got %v", got)
+	}
+}
+
+func TestResultMetrics(t *testing.T) {
+	res, err := backtest.NewEngine(1000).Run(backtestBars(), func(ctx *backtest.Context) error {
+		switch ctx.Index() {
+		case 0:
+			ctx.Buy(10)
+		case 2:
+			ctx.ClosePosition()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m := res.Metrics(252)
+	// Buys at 11 on bar 1 and sells at 13 on bar 3.
+	if !sameFloat(m.TotalReturn, 0.02) || !sameFloat(m.Exposure, 0.4) || m.Trades.Count != 1 || m.Trades.WinRate != 1 {
+		t.Errorf("got %+v", m)
+	}
+	if m.MaxDrawdown != 0 || !math.IsNaN(m.Calmar) || math.IsNaN(m.Sharpe) {
+		t.Errorf("got %+v", m)
+	}
+}
@@ -0,0 +1,27 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestMomentum(t *testing.T) {
+	data := []float64{10, 11, 12, 11, 13}
+	mom := indicators.NewMomentum(2)
+	got, err := mom.Calculate(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !math.IsNaN(got[0]) || !math.IsNaN(got[1]) {
+		t.Errorf("expected NaN warm-up values, got %v, %v", got[0], got[1])
+	}
+	want := []float64{2, 0, 1}
+	for i, w := range want {
+		if got[i+2] != w {
+			t.Errorf("index %d: got %.2f, want %.2f", i+2, got[i+2], w)
+		}
+	}
+}
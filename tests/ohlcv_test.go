@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestOHLCVValidate(t *testing.T) {
+	bars := &indicators.OHLCV{
+		High:  []float64{11, 12, 13},
+		Low:   []float64{9, 10, 11},
+		Close: []float64{10, 11, 12},
+	}
+	if err := bars.Validate(); err != nil {
+		t.Fatalf("unexpected error for optional fields left empty: %v", err)
+	}
+	if bars.Len() != 3 {
+		t.Errorf("Len got %d, want 3", bars.Len())
+	}
+
+	bars.Open = []float64{10, 11}
+	if err := bars.Validate(); err == nil {
+		t.Error("expected error for mismatched open length")
+	}
+
+	empty := &indicators.OHLCV{}
+	if err := empty.Validate(); err == nil {
+		t.Error("expected error for an empty series")
+	}
+}
+
+func TestAlignByTime(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	a := &indicators.OHLCV{
+		Time:  []time.Time{day(1), day(2), day(3), day(5)},
+		High:  []float64{11, 12, 13, 15},
+		Low:   []float64{9, 10, 11, 13},
+		Close: []float64{10, 11, 12, 14},
+	}
+	b := &indicators.OHLCV{
+		Time:   []time.Time{day(2), day(3), day(4), day(5)},
+		High:   []float64{21, 22, 23, 24},
+		Low:    []float64{19, 20, 21, 22},
+		Close:  []float64{20, 21, 22, 23},
+		Volume: []float64{1, 2, 3, 4},
+	}
+	ga, gb, err := indicators.AlignByTime(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantA := []float64{11, 12, 14}
+	wantB := []float64{20, 21, 23}
+	if ga.Len() != 3 || gb.Len() != 3 {
+		t.Fatalf("got lengths %d and %d, want 3", ga.Len(), gb.Len())
+	}
+	for i := range wantA {
+		if ga.Close[i] != wantA[i] || gb.Close[i] != wantB[i] || !ga.Time[i].Equal(gb.Time[i]) {
+			t.Errorf("index %d: got %v/%v at %v/%v", i, ga.Close[i], gb.Close[i], ga.Time[i], gb.Time[i])
+		}
+	}
+	if ga.Volume != nil || len(gb.Volume) != 3 || gb.Volume[2] != 4 {
+		t.Errorf("optional fields not carried over correctly: %v, %v", ga.Volume, gb.Volume)
+	}
+
+	a.Time = nil
+	if _, _, err := indicators.AlignByTime(a, b); err == nil {
+		t.Error("expected error for missing timestamps")
+	}
+}
+
+// waveBars returns n bars of a trending sine wave with uneven ranges and gaps, which
+// exercises trend flips in the stream-vs-batch tests.
+func waveBars(n int) *indicators.OHLCV {
+	bars := &indicators.OHLCV{}
+	prev := 100.0
+	for i := 0; i < n; i++ {
+		c := 100 + 0.2*float64(i) + 8*math.Sin(float64(i)/4) + 2*math.Sin(float64(i)*1.7)
+		r := 1 + 0.5*math.Abs(math.Cos(float64(i)))
+		bars.Open = append(bars.Open, prev)
+		bars.High = append(bars.High, math.Max(prev, c)+r)
+		bars.Low = append(bars.Low, math.Min(prev, c)-r)
+		bars.Close = append(bars.Close, c)
+		prev = c
+	}
+	return bars
+}
@@ -0,0 +1,49 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestPairSpread(t *testing.T) {
+	x := []float64{10, 11, 12, 13, 14, 15, 16}
+	y := make([]float64, len(x))
+	for i, v := range x {
+		y[i] = 5 + 1.5*v
+	}
+	// last bar diverges from the relationship
+	y[6] += 1
+
+	ps := indicators.NewPairSpread(3, 3)
+	res, err := ps.Calculate(y, x)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !math.IsNaN(res.HedgeRatio[1]) || !math.IsNaN(res.ZScore[3]) {
+		t.Error("expected NaN warm-up values")
+	}
+	for i := 2; i < 6; i++ {
+		if math.Abs(res.HedgeRatio[i]-1.5) > 1e-9 || math.Abs(res.Intercept[i]-5) > 1e-9 {
+			t.Errorf("index %d: hedge %.5f intercept %.5f", i, res.HedgeRatio[i], res.Intercept[i])
+		}
+		if math.Abs(res.Spread[i]-5) > 1e-9 {
+			t.Errorf("index %d: spread %.5f, want 5", i, res.Spread[i])
+		}
+	}
+	// spreads at 2..5 are constant => z-score undefined
+	if !math.IsNaN(res.ZScore[4]) {
+		t.Errorf("expected NaN z-score for constant spread, got %.5f", res.ZScore[4])
+	}
+	// window at index 6: y = {26, 27.5, 30}, x = {14, 15, 16} => hedge 2
+	if math.Abs(res.HedgeRatio[6]-2) > 1e-9 {
+		t.Errorf("hedge at 6: got %.5f, want 2", res.HedgeRatio[6])
+	}
+	spread := 30 - 2*16.0
+	mean := (5 + 5 + spread) / 3
+	std := math.Sqrt((2*(5-mean)*(5-mean) + (spread-mean)*(spread-mean)) / 2)
+	if math.Abs(res.ZScore[6]-(spread-mean)/std) > 1e-9 {
+		t.Errorf("z-score at 6: got %.5f, want %.5f", res.ZScore[6], (spread-mean)/std)
+	}
+}
@@ -35,3 +35,91 @@ func TestParabolicSAR(t *testing.T) {
 	// For demonstration, we'll just print the final SAR for visual inspection.
 	t.Logf("Final Parabolic SAR value: %.4f", sarValues[len(sarValues)-1])
 }
+
+func TestParabolicSARState(t *testing.T) {
+	// Rally then sell-off so the SAR must reverse at least once.
+	highs := []float64{10, 10.5, 11, 11.6, 12.2, 12.5, 12.1, 11.4, 10.8, 10.2, 9.8}
+	lows := []float64{9.5, 10.0, 10.4, 11.0, 11.6, 11.9, 11.2, 10.6, 10.0, 9.5, 9.1}
+
+	psar := indicators.NewParabolicSAR(0.02, 0.02, 0.2)
+	res, err := psar.CalculateState(highs, lows)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res.SAR) != len(highs) || len(res.Direction) != len(highs) ||
+		len(res.EP) != len(highs) || len(res.AF) != len(highs) || len(res.Reversal) != len(highs) {
+		t.Fatalf("all state slices must match input length")
+	}
+
+	sarOnly, _ := psar.Calculate(highs, lows)
+	reversals := 0
+	for i := range highs {
+		if sarOnly[i] != res.SAR[i] {
+			t.Errorf("index %d: Calculate and CalculateState disagree", i)
+		}
+		if res.AF[i] < 0.02 || res.AF[i] > 0.2+1e-12 {
+			t.Errorf("index %d: AF %.4f outside [start, max]", i, res.AF[i])
+		}
+		if i > 0 && res.Reversal[i] != (res.Direction[i] != res.Direction[i-1]) {
+			t.Errorf("index %d: reversal flag inconsistent with direction change", i)
+		}
+		if res.Reversal[i] {
+			reversals++
+			if res.AF[i] != 0.02 {
+				t.Errorf("index %d: AF should reset on reversal, got %.4f", i, res.AF[i])
+			}
+		}
+	}
+	if res.Direction[0] != 1 {
+		t.Errorf("expected auto-detected uptrend at bar 0, got %d", res.Direction[0])
+	}
+	if reversals == 0 || res.Direction[len(highs)-1] != -1 {
+		t.Error("expected the sell-off to reverse the SAR into a downtrend")
+	}
+
+	// Forcing the initial trend overrides auto-detection.
+	psar.InitialTrend = -1
+	forced, err := psar.CalculateState(highs, lows)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if forced.Direction[0] != -1 || forced.SAR[0] != highs[0] {
+		t.Errorf("expected forced downtrend at bar 0, got dir=%d sar=%.2f", forced.Direction[0], forced.SAR[0])
+	}
+}
+
+func TestParabolicSARStream(t *testing.T) {
+	bars := waveBars(120)
+	for _, initial := range []int{0, 1, -1} {
+		p := indicators.NewParabolicSAR(0.02, 0.02, 0.2)
+		p.InitialTrend = initial
+		res, err := p.CalculateState(bars.High, bars.Low)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		stream, err := p.NewStream()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		reversals := 0
+		for i := range bars.High {
+			sar, dir, rev := stream.Update(bars.High[i], bars.Low[i])
+			if i == 0 && initial == 0 {
+				if !math.IsNaN(sar) || dir != 0 {
+					t.Errorf("auto-detect: first bar got (%v, %d), want NaN", sar, dir)
+				}
+				continue
+			}
+			if !sameFloat(sar, res.SAR[i]) || dir != res.Direction[i] || rev != res.Reversal[i] {
+				t.Errorf("initial %d index %d: stream (%v, %d, %v) != batch (%v, %d, %v)",
+					initial, i, sar, dir, rev, res.SAR[i], res.Direction[i], res.Reversal[i])
+			}
+			if rev {
+				reversals++
+			}
+		}
+		if reversals == 0 {
+			t.Errorf("initial %d: expected the test data to reverse the SAR", initial)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestPivotPoints(t *testing.T) {
+	at := func(d, h int) time.Time { return time.Date(2024, 3, d, h, 0, 0, 0, time.UTC) }
+	bars := &indicators.OHLCV{
+		Time:  []time.Time{at(4, 10), at(4, 15), at(5, 10), at(5, 15)},
+		Open:  []float64{10, 11, 12, 12.5},
+		High:  []float64{12, 13, 13, 14},
+		Low:   []float64{9, 10, 11.5, 12},
+		Close: []float64{11, 12, 12.5, 13.5},
+	}
+
+	// prior session: O=10, H=13, L=9, C=12
+	res, err := indicators.NewPivotPoints(indicators.PivotClassic).Calculate(bars)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !math.IsNaN(res.P[0]) || !math.IsNaN(res.S1[1]) {
+		t.Error("expected NaN levels during the first session")
+	}
+	p := 34.0 / 3
+	checks := map[string][2]float64{
+		"P":  {res.P[2], p},
+		"R1": {res.R1[3], 2*p - 9},
+		"S1": {res.S1[3], 2*p - 13},
+		"R2": {res.R2[2], p + 4},
+		"S3": {res.S3[2], 9 - 2*(13-p)},
+	}
+	for name, c := range checks {
+		if math.Abs(c[0]-c[1]) > 1e-9 {
+			t.Errorf("classic %s: got %.5f, want %.5f", name, c[0], c[1])
+		}
+	}
+	if !math.IsNaN(res.R4[2]) || res.Session[1] != 0 || res.Session[2] != 1 {
+		t.Errorf("expected NaN R4 and sessions 0/1, got %v %v", res.R4[2], res.Session)
+	}
+
+	lv, _ := indicators.PivotFibonacci.Levels(10, 13, 9, 12)
+	if math.Abs(lv.R2-(p+0.618*4)) > 1e-9 {
+		t.Errorf("fibonacci R2: got %.5f", lv.R2)
+	}
+	lv, _ = indicators.PivotWoodie.Levels(10, 13, 9, 12)
+	if math.Abs(lv.P-11.5) > 1e-9 {
+		t.Errorf("woodie P: got %.5f, want 11.5", lv.P)
+	}
+	lv, _ = indicators.PivotCamarilla.Levels(10, 13, 9, 12)
+	if math.Abs(lv.R4-14.2) > 1e-9 || math.Abs(lv.S1-(12-4*1.1/12)) > 1e-9 {
+		t.Errorf("camarilla: R4 %.5f S1 %.5f", lv.R4, lv.S1)
+	}
+
+	// DeMark, close > open: X = 2H + L + C = 47
+	res, err = indicators.NewPivotPoints(indicators.PivotDeMark).Calculate(bars)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(res.P[2]-11.75) > 1e-9 || math.Abs(res.R1[2]-14.5) > 1e-9 || math.Abs(res.S1[2]-10.5) > 1e-9 {
+		t.Errorf("demark: P %.5f R1 %.5f S1 %.5f", res.P[2], res.R1[2], res.S1[2])
+	}
+	if !math.IsNaN(res.R2[2]) {
+		t.Errorf("demark R2 should be NaN, got %.5f", res.R2[2])
+	}
+
+	// an 18:00 session start moves the 19:00 bar into the next session
+	evening := &indicators.OHLCV{
+		Time:  []time.Time{at(4, 10), at(4, 19), at(5, 10)},
+		High:  []float64{12, 13, 14},
+		Low:   []float64{9, 10, 11},
+		Close: []float64{11, 12, 13},
+	}
+	pp := indicators.NewPivotPoints(indicators.PivotClassic)
+	pp.SessionOffset = -6 * time.Hour
+	res, err = pp.Calculate(evening)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Session[1] != 1 || res.Session[2] != 1 || math.Abs(res.P[1]-32.0/3) > 1e-9 {
+		t.Errorf("session offset: sessions %v, P %.5f", res.Session, res.P[1])
+	}
+
+	bars.Time = nil
+	if _, err := indicators.NewPivotPoints(indicators.PivotClassic).Calculate(bars); err == nil {
+		t.Error("expected error for missing timestamps")
+	}
+}
@@ -0,0 +1,42 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestPointAndFigure(t *testing.T) {
+	closes := []float64{10, 12.5, 14, 13, 11, 10.5, 9, 12.2}
+	bars := &indicators.OHLCV{High: closes, Low: closes, Close: closes}
+	res, err := indicators.NewPointAndFigure(1, 3).Calculate(bars)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// X column 10..14 (bars 1-2); 13 is not a 3-box reversal, 11 is: O column 13..11 (bar 4),
+	// extended to 9 (bar 6); 12.2 reverses 3 boxes: X column 10..12 (bar 7)
+	wantDir := []int{1, -1, 1}
+	wantHigh := []float64{14, 13, 12}
+	wantLow := []float64{10, 9, 10}
+	wantBoxes := []int{5, 5, 3}
+	wantStart := []int{1, 4, 7}
+	wantLast := []int{2, 6, 7}
+	if len(res.Direction) != len(wantDir) {
+		t.Fatalf("got %d columns, want %d", len(res.Direction), len(wantDir))
+	}
+	for i := range wantDir {
+		if res.Direction[i] != wantDir[i] || res.Bars.High[i] != wantHigh[i] || res.Bars.Low[i] != wantLow[i] ||
+			res.Boxes[i] != wantBoxes[i] || res.StartIndex[i] != wantStart[i] || res.SourceIndex[i] != wantLast[i] {
+			t.Errorf("column %d: dir %d high %.1f low %.1f boxes %d start %d last %d", i,
+				res.Direction[i], res.Bars.High[i], res.Bars.Low[i], res.Boxes[i], res.StartIndex[i], res.SourceIndex[i])
+		}
+	}
+	if res.Bars.Open[1] != 13 || res.Bars.Close[1] != 9 {
+		t.Errorf("O column should open at 13 and close at 9, got %.1f/%.1f", res.Bars.Open[1], res.Bars.Close[1])
+	}
+
+	if _, err := indicators.NewPointAndFigure(1, 0).Calculate(bars); err == nil {
+		t.Error("expected error for invalid reversal")
+	}
+}
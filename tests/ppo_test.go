@@ -0,0 +1,36 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestPPO(t *testing.T) {
+	data := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	ppo := indicators.NewPPO(3, 6, 2)
+	ppoLine, signalLine, hist, err := ppo.Calculate(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ppoLine) != len(data) || len(signalLine) != len(data) || len(hist) != len(data) {
+		t.Errorf("all PPO slices must match input length")
+	}
+
+	// PPO should equal the MACD line divided by the slow EMA.
+	macdLine, _, _, err := indicators.NewMACD(3, 6, 2).Calculate(data)
+	if err != nil {
+		t.Fatalf("unexpected MACD error: %v", err)
+	}
+	slow, _ := indicators.NewEMA(6).Calculate(data)
+	for i := range data {
+		want := macdLine[i] / slow[i] * 100
+		if math.Abs(ppoLine[i]-want) > 1e-9 {
+			t.Errorf("index %d: got %.5f, want %.5f", i, ppoLine[i], want)
+		}
+		if math.Abs(hist[i]-(ppoLine[i]-signalLine[i])) > 1e-9 {
+			t.Errorf("index %d: histogram mismatch", i)
+		}
+	}
+}
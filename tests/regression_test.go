@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestLinearRegression(t *testing.T) {
+	// Perfect line y = 2x + 1 => slope 2, R² 1, zero standard error
+	data := []float64{1, 3, 5, 7, 9, 11}
+	lr := indicators.NewLinearRegression(3, 2)
+	res, err := lr.CalculateAll(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !math.IsNaN(res.Value[0]) || !math.IsNaN(res.Slope[1]) {
+		t.Error("expected NaN warm-up values")
+	}
+	for i := 2; i < len(data); i++ {
+		if math.Abs(res.Value[i]-data[i]) > 1e-9 {
+			t.Errorf("index %d: value %.5f, want %.5f", i, res.Value[i], data[i])
+		}
+		if math.Abs(res.Slope[i]-2) > 1e-9 || math.Abs(res.RSquared[i]-1) > 1e-9 {
+			t.Errorf("index %d: slope %.5f, r2 %.5f", i, res.Slope[i], res.RSquared[i])
+		}
+		if math.Abs(res.Intercept[i]-data[i-2]) > 1e-9 {
+			t.Errorf("index %d: intercept %.5f, want %.5f", i, res.Intercept[i], data[i-2])
+		}
+		if math.Abs(res.Forecast[i]-(data[i]+2)) > 1e-9 {
+			t.Errorf("index %d: forecast %.5f, want %.5f", i, res.Forecast[i], data[i]+2)
+		}
+		if res.StdErr[i] > 1e-9 || math.Abs(res.Upper[i]-res.Value[i]) > 1e-9 {
+			t.Errorf("index %d: expected zero-width bands", i)
+		}
+	}
+	if math.Abs(res.Angle[5]-math.Atan(2)*180/math.Pi) > 1e-9 {
+		t.Errorf("angle %.5f", res.Angle[5])
+	}
+
+	// Noisy window {1, 3, 2}: slope 0.5, intercept 1.5, value 2.5,
+	// residuals -0.5, 1, -0.5 => SSE 1.5, stderr sqrt(1.5), R² = 1 - 1.5/2 = 0.25
+	res, err = lr.CalculateAll([]float64{1, 3, 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(res.Slope[2]-0.5) > 1e-9 || math.Abs(res.Value[2]-2.5) > 1e-9 {
+		t.Errorf("slope %.5f value %.5f", res.Slope[2], res.Value[2])
+	}
+	if math.Abs(res.RSquared[2]-0.25) > 1e-9 {
+		t.Errorf("r2 %.5f, want 0.25", res.RSquared[2])
+	}
+	if math.Abs(res.Upper[2]-(2.5+2*math.Sqrt(1.5))) > 1e-9 {
+		t.Errorf("upper %.5f", res.Upper[2])
+	}
+
+	tsf, err := indicators.NewTimeSeriesForecast(3).Calculate([]float64{1, 3, 2})
+	if err != nil || math.Abs(tsf[2]-3) > 1e-9 {
+		t.Errorf("tsf %v, err %v", tsf, err)
+	}
+
+	if _, err := lr.Calculate([]float64{1, 2}); err == nil {
+		t.Error("expected error for insufficient data")
+	}
+}
@@ -0,0 +1,61 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestRenko(t *testing.T) {
+	closes := []float64{10, 11.5, 13.2, 12.5, 11, 10.4, 8.9}
+	bars := &indicators.OHLCV{
+		High:   closes,
+		Low:    closes,
+		Close:  closes,
+		Volume: []float64{1, 1, 1, 1, 1, 1, 1},
+	}
+	res, err := indicators.NewRenko(1).Calculate(bars)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// up 10->11 (bar 1), 11->12, 12->13 (bar 2); reversal needs <= 11: down 12->11 (bar 4);
+	// continuation needs <= 10: 11->10 (bar 6), 10->9 (bar 6)
+	wantDir := []int{1, 1, 1, -1, -1, -1}
+	wantSrc := []int{1, 2, 2, 4, 6, 6}
+	wantClose := []float64{11, 12, 13, 11, 10, 9}
+	wantVol := []float64{2, 1, 0, 2, 2, 0}
+	if len(res.Direction) != len(wantDir) {
+		t.Fatalf("got %d bricks (%v), want %d", len(res.Direction), res.Bars.Close, len(wantDir))
+	}
+	for i := range wantDir {
+		if res.Direction[i] != wantDir[i] || res.SourceIndex[i] != wantSrc[i] ||
+			res.Bars.Close[i] != wantClose[i] || res.Bars.Volume[i] != wantVol[i] {
+			t.Errorf("brick %d: dir %d src %d close %.2f vol %.0f, want %d %d %.2f %.0f", i,
+				res.Direction[i], res.SourceIndex[i], res.Bars.Close[i], res.Bars.Volume[i],
+				wantDir[i], wantSrc[i], wantClose[i], wantVol[i])
+		}
+	}
+	if res.Bars.Open[3] != 12 || res.Bars.High[3] != 12 || res.Bars.Low[3] != 11 {
+		t.Errorf("reversal brick: open %.2f high %.2f low %.2f", res.Bars.Open[3], res.Bars.High[3], res.Bars.Low[3])
+	}
+
+	// ATR-sized bricks: every bar has a range of 2 so the box is 2
+	atrBars := &indicators.OHLCV{
+		High:  []float64{11, 12, 13, 14, 15},
+		Low:   []float64{9, 10, 11, 12, 13},
+		Close: []float64{10, 11, 12, 13, 14},
+	}
+	res, err = indicators.NewATRRenko(2).Calculate(atrBars)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// base is the close at bar 1 (11); 13 at bar 3 makes the first brick
+	if len(res.SourceIndex) != 1 || res.SourceIndex[0] != 3 || res.Bars.Close[0] != 13 {
+		t.Errorf("ATR renko: got src %v close %v", res.SourceIndex, res.Bars.Close)
+	}
+
+	if _, err := indicators.NewRenko(0).Calculate(bars); err == nil {
+		t.Error("expected error for zero box size")
+	}
+}
@@ -0,0 +1,34 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestROC(t *testing.T) {
+	data := []float64{10, 11, 12, 11, 13.2}
+	roc := indicators.NewROC(2)
+	got, err := roc.Calculate(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// i=2 => (12-10)/10*100 = 20
+	// i=3 => (11-11)/11*100 = 0
+	// i=4 => (13.2-12)/12*100 = 10
+	if !math.IsNaN(got[0]) || !math.IsNaN(got[1]) {
+		t.Errorf("expected NaN warm-up values, got %v, %v", got[0], got[1])
+	}
+	want := []float64{20, 0, 10}
+	for i, w := range want {
+		if math.Abs(got[i+2]-w) > 1e-9 {
+			t.Errorf("index %d: got %.5f, want %.5f", i+2, got[i+2], w)
+		}
+	}
+
+	if _, err := roc.Calculate([]float64{1, 2}); err == nil {
+		t.Error("expected error for insufficient data")
+	}
+}
@@ -28,3 +28,123 @@ func TestRSI(t *testing.T) {
 		t.Error("expected final RSI not to be NaN")
 	}
 }
+
+func TestRSISmoothingVariants(t *testing.T) {
+	data := []float64{10, 11, 10, 12, 13, 12, 14}
+
+	// Cutler's RSI: simple averages of the last 3 changes.
+	// i=3: changes +1, -1, +2 => gains 3, losses 1 => RSI = 100 - 100/(1+3) = 75
+	// i=4: changes -1, +2, +1 => 75
+	// i=5: changes +2, +1, -1 => 75
+	// i=6: changes +1, -1, +2 => 75
+	cutler, err := indicators.NewCutlerRSI(3).Calculate(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 3; i < len(data); i++ {
+		if math.Abs(cutler[i]-75) > 1e-9 {
+			t.Errorf("index %d: Cutler RSI got %.4f, want 75", i, cutler[i])
+		}
+	}
+
+	wilder, err := indicators.NewRSI(3).Calculate(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	emaRSI := &indicators.RSI{Window: 3, Smoothing: indicators.MAExponential}
+	ema, err := emaRSI.Calculate(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// All variants share the same seed (simple average of the first window).
+	if wilder[3] != cutler[3] || ema[3] != cutler[3] {
+		t.Errorf("expected identical seeds, got wilder=%.4f ema=%.4f cutler=%.4f", wilder[3], ema[3], cutler[3])
+	}
+	if wilder[6] == ema[6] {
+		t.Error("expected Wilder and EMA smoothing to diverge after the seed")
+	}
+
+	// Only gains => RSI is 100 from the first valid bar.
+	up, err := indicators.NewRSI(2).Calculate([]float64{1, 2, 3, 4})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if up[2] != 100 || up[3] != 100 {
+		t.Errorf("expected RSI 100 for a steady rally, got %v", up)
+	}
+
+	if _, err := indicators.NewRSI(3).Calculate([]float64{1, 2, 3}); err == nil {
+		t.Error("expected error when there are no complete windows of changes")
+	}
+}
+
+func TestRSIStream(t *testing.T) {
+	data := []float64{44, 44.3, 44.1, 43.6, 44.3, 44.8, 45.1, 45.4, 45.8, 46.1, 45.9, 46.2, 45.6, 46.3}
+	for _, rsi := range []*indicators.RSI{indicators.NewRSI(5), indicators.NewCutlerRSI(5)} {
+		batch, err := rsi.Calculate(data)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		stream, err := rsi.NewStream()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for i, p := range data {
+			got := stream.Update(p)
+			if i < rsi.Window {
+				if !math.IsNaN(got) {
+					t.Errorf("index %d: got %v during warm-up, want NaN", i, got)
+				}
+				continue
+			}
+			if !sameFloat(got, batch[i]) {
+				t.Errorf("smoothing %d index %d: stream %v != batch %v", rsi.Smoothing, i, got, batch[i])
+			}
+		}
+	}
+}
+
+func TestRSIAdaptive(t *testing.T) {
+	prices := make([]float64, 150)
+	for i := range prices {
+		prices[i] = 100 + 5*math.Sin(2*math.Pi*float64(i)/20) + 0.05*float64(i)
+	}
+
+	// A constant period is Cutler's RSI.
+	constant := make([]float64, len(prices))
+	for i := range constant {
+		constant[i] = 14
+	}
+	want, _ := indicators.NewCutlerRSI(14).Calculate(prices)
+	got, err := indicators.AdaptiveCutlerRSI(prices, constant)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range want {
+		if i < 14 {
+			if !math.IsNaN(got[i]) {
+				t.Errorf("index %d: got %v, want NaN", i, got[i])
+			}
+			continue
+		}
+		if !sameFloat(got[i], want[i]) {
+			t.Fatalf("index %d: adaptive %v != Cutler %v", i, got[i], want[i])
+		}
+	}
+
+	// Half the dominant cycle as the lookback.
+	cycle, _, _ := indicators.NewHilbertDominantCycle().Calculate(prices)
+	half := make([]float64, len(cycle))
+	for i, c := range cycle {
+		half[i] = c / 2
+	}
+	got, err = indicators.AdaptiveCutlerRSI(prices, half)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 60; i < len(got); i++ {
+		if math.IsNaN(got[i]) || got[i] < 0 || got[i] > 100 {
+			t.Errorf("index %d: got %v, want a value in [0, 100]", i, got[i])
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package tests
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+	"github.com/copyleftdev/indicator-libs/rules"
+)
+
+func ruleBars(closes []float64) *indicators.OHLCV {
+	bars := &indicators.OHLCV{}
+	for _, c := range closes {
+		bars.High = append(bars.High, c+1)
+		bars.Low = append(bars.Low, c-1)
+		bars.Close = append(bars.Close, c)
+	}
+	return bars
+}
+
+func TestRuleEvaluate(t *testing.T) {
+	bars := ruleBars([]float64{10, 11, 12, 11, 13, 14, 12, 15})
+
+	r, err := rules.Compile("close > close[1] and not (close > 14)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !r.IsCondition() {
+		t.Fatal("expected a condition")
+	}
+	got, err := r.Evaluate(bars)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []bool{false, true, true, false, true, true, false, false}
+	if !sameBools(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Keywords are case-insensitive and && / || / ! are accepted.
+	r2, err := rules.Compile("CLOSE > close[1] && !(close > 14)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got2, _ := r2.Evaluate(bars); !sameBools(got2, want) {
+		t.Errorf("aliases: got %v, want %v", got2, want)
+	}
+}
+
+func TestRuleWarmUpIsFalse(t *testing.T) {
+	bars := ruleBars([]float64{10, 11, 12, 13, 14, 15})
+	r, err := rules.Compile("close >= sma(3)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := r.Evaluate(bars)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []bool{false, false, true, true, true, true}
+	if !sameBools(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Comparisons with a warm-up value are false whichever way they point.
+	lt, _ := rules.Compile("close < sma(3)")
+	if got, _ := lt.Evaluate(bars); !sameBools(got, make([]bool, 6)) {
+		t.Errorf("close < sma(3): got %v", got)
+	}
+}
+
+func TestRuleNotWarmUpIsFalse(t *testing.T) {
+	bars := ruleBars([]float64{10, 11, 12, 11, 10, 9})
+	cases := []struct {
+		src  string
+		want []bool
+	}{
+		{"not (close < sma(3))", []bool{false, false, true, false, false, false}},
+		{"not (close < close[1])", []bool{false, true, true, false, false, false}},
+		{"not rising(close, 2)", []bool{false, false, false, true, true, true}},
+		{"not crossunder(close, sma(2))", []bool{false, false, true, false, true, true}},
+		{"not (close > sma(3)) or close > 0", []bool{false, false, true, true, true, true}},
+	}
+	for _, c := range cases {
+		r, err := rules.Compile(c.src)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.src, err)
+		}
+		got, err := r.Evaluate(bars)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.src, err)
+		}
+		if !sameBools(got, c.want) {
+			t.Errorf("%s: got %v, want %v", c.src, got, c.want)
+		}
+	}
+}
+
+func TestRuleValues(t *testing.T) {
+	bars := ruleBars([]float64{10, 12, 14, 16})
+	r, err := rules.Compile("(close - sma(2)) * 2 / -1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.IsCondition() {
+		t.Fatal("expected a numeric expression")
+	}
+	got, err := r.Values(bars)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []float64{math.NaN(), -2, -2, -2}
+	for i := range want {
+		if !sameFloat(got[i], want[i]) {
+			t.Errorf("index %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if _, err := r.Evaluate(bars); err == nil {
+		t.Error("expected error evaluating a number as a condition")
+	}
+	div, _ := rules.Compile("close / (close - close)")
+	if v, _ := div.Values(bars); !math.IsNaN(v[0]) {
+		t.Errorf("division by zero: got %v, want NaN", v[0])
+	}
+}
+
+func TestRuleIndicatorsAndFields(t *testing.T) {
+	closes := make([]float64, 60)
+	for i := range closes {
+		closes[i] = 100 + 10*math.Sin(float64(i)/5)
+	}
+	bars := ruleBars(closes)
+
+	exprs := []string{
+		"rsi(14) < 30 and close > supertrend(10, 3).line and adx(14) > 25",
+		"crossover(macd(12, 26, 9), macd(12, 26, 9).signal)",
+		"close > bbands(20, 2).upper or close < bbands().lower",
+		"ema(rsi(14), 9) > 50",
+		"stoch(14, 3).k > stoch(14, 3).d and rising(sma(hl2, 5), 2)",
+		"highest(high, 10)[1] < close",
+	}
+	for _, src := range exprs {
+		r, err := rules.Compile(src)
+		if err != nil {
+			t.Errorf("%s: unexpected compile error: %v", src, err)
+			continue
+		}
+		got, err := r.Evaluate(bars)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", src, err)
+			continue
+		}
+		if len(got) != len(closes) {
+			t.Errorf("%s: got %d values, want %d", src, len(got), len(closes))
+		}
+	}
+
+	// A field selects the same series the indicator computes directly.
+	r, _ := rules.Compile("macd(12, 26, 9).signal")
+	got, err := r.Values(bars)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, signal, _, _ := indicators.NewMACD(12, 26, 9).Calculate(closes)
+	for i := 34; i < len(closes); i++ {
+		if math.Abs(got[i]-signal[i]) > 1e-9 {
+			t.Fatalf("index %d: got %v, want %v", i, got[i], signal[i])
+		}
+	}
+
+	cross, _ := rules.Compile("crossover(close, 100)")
+	over, _ := cross.Evaluate(bars)
+	for i := 1; i < len(closes); i++ {
+		want := closes[i-1] <= 100 && closes[i] > 100
+		if over[i] != want {
+			t.Errorf("crossover index %d: got %v, want %v", i, over[i], want)
+		}
+	}
+}
+
+func TestRuleCompileErrors(t *testing.T) {
+	cases := []struct {
+		src    string
+		column int
+		msg    string
+	}{
+		{"", 1, "empty expression"},
+		{"rsx(14) < 30", 1, "unknown function"},
+		{"close > foo", 9, "unknown name"},
+		{"rsi() < 30", 1, "rsi expects"},
+		{"rsi(14, 2, 3) < 30", 1, "rsi expects"},
+		{"rsi(0) < 30", 5, "positive whole number"},
+		{"rsi(14.5) < 30", 5, "positive whole number"},
+		{"macd().foo > 0", 7, "no output"},
+		{"rsi(14).line > 0", 8, "single output"},
+		{"rsi(14) and close > 1", 1, "expects a condition"},
+		{"close > 1 + (close > 2)", 20, "expects a number"},
+		{"1 < close < 2", 3, "can't be chained"},
+		{"close[1.5] > 0", 7, "lookback"},
+		{"close > $", 9, "unexpected character"},
+		{"é > 1", 1, "unexpected character 'é'"},
+		{"close > \xff", 9, "invalid UTF-8"},
+		{"close ≥ 1", 7, "unexpected character '≥'"},
+		{"(close > 1", 11, "expected ')'"},
+		{"rsi > 30", 1, "is a function"},
+	}
+	for _, c := range cases {
+		_, err := rules.Compile(c.src)
+		if err == nil {
+			t.Errorf("%q: expected an error", c.src)
+			continue
+		}
+		var rerr *rules.Error
+		if !errors.As(err, &rerr) {
+			t.Errorf("%q: got %T, want *rules.Error", c.src, err)
+			continue
+		}
+		if rerr.Column != c.column || !strings.Contains(rerr.Msg, c.msg) {
+			t.Errorf("%q: got column %d %q, want column %d containing %q", c.src, rerr.Column, rerr.Msg, c.column, c.msg)
+		}
+	}
+}
+
+func TestRuleMissingVolume(t *testing.T) {
+	r, err := rules.Compile("obv() > 0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := r.Evaluate(ruleBars([]float64{1, 2, 3})); err == nil {
+		t.Error("expected error for bars without volume")
+	}
+}
+
+func TestRuleADXWarmUp(t *testing.T) {
+	closes := make([]float64, 40)
+	for i := range closes {
+		closes[i] = 100 + float64(i)
+	}
+	bars := ruleBars(closes)
+	cases := []struct {
+		src  string
+		adx  *indicators.ADX
+		warm int
+	}{
+		{"adx(14)", indicators.NewADX(14), 13},
+		{"adx(14, 14)", indicators.NewADXWithSmoothing(14, 14), 26},
+		{"adx(10, 5).adx", indicators.NewADXWithSmoothing(10, 5), 13},
+	}
+	for _, c := range cases {
+		r, err := rules.Compile(c.src)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.src, err)
+		}
+		got, err := r.Values(bars)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.src, err)
+		}
+		dmi, _ := c.adx.CalculateDMI(bars.High, bars.Low, bars.Close)
+		for i := range got {
+			if i < c.warm {
+				if !math.IsNaN(got[i]) {
+					t.Errorf("%s index %d: got %v during warm-up, want NaN", c.src, i, got[i])
+				}
+			} else if !sameFloat(got[i], dmi.ADX[i]) {
+				t.Errorf("%s index %d: got %v, want %v", c.src, i, got[i], dmi.ADX[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/signals"
+)
+
+func sameBools(a, b []bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCrossOverUnder(t *testing.T) {
+	nan := math.NaN()
+	macd := []float64{nan, 1, 2, 3, 2, 1, 2}
+	signal := []float64{nan, 2, 2, 2, 2, 2, 2}
+
+	over, err := signals.CrossOver(macd, signal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	under, _ := signals.CrossUnder(macd, signal)
+	wantOver := []bool{false, false, false, true, false, false, false}
+	wantUnder := []bool{false, false, false, false, false, true, false}
+	if !sameBools(over, wantOver) || !sameBools(under, wantUnder) {
+		t.Errorf("got over %v under %v", over, under)
+	}
+
+	rsi := []float64{35, 28, 31, 29, 33}
+	if got := signals.CrossAbove(rsi, 30); !sameBools(got, []bool{false, false, true, false, true}) {
+		t.Errorf("CrossAbove: got %v", got)
+	}
+	if got := signals.Events(signals.CrossBelow(rsi, 30)); len(got) != 2 || got[0] != 1 || got[1] != 3 {
+		t.Errorf("CrossBelow events: got %v", got)
+	}
+
+	// the stream matches the vectorized result
+	s := signals.NewCrossStream()
+	for i := range macd {
+		o, u := s.Update(macd[i], signal[i])
+		if o != over[i] || u != under[i] {
+			t.Errorf("stream index %d: got %v/%v", i, o, u)
+		}
+	}
+
+	if _, err := signals.CrossOver(macd, signal[:3]); err == nil {
+		t.Error("expected error for mismatched lengths")
+	}
+}
+
+func TestHysteresis(t *testing.T) {
+	adx := []float64{18, 26, 22, 19, 24, 25}
+	want := []bool{false, true, true, false, false, true}
+	got := signals.HysteresisAbove(adx, 25, 20)
+	if !sameBools(got, want) {
+		t.Errorf("HysteresisAbove: got %v, want %v", got, want)
+	}
+
+	rsi := []float64{40, 29, 45, 51, 35, 30}
+	wantBelow := []bool{false, true, true, false, false, true}
+	gotBelow := signals.HysteresisBelow(rsi, 30, 50)
+	if !sameBools(gotBelow, wantBelow) {
+		t.Errorf("HysteresisBelow: got %v, want %v", gotBelow, wantBelow)
+	}
+
+	s := signals.NewHysteresisStream(30, 50, true)
+	for i, v := range rsi {
+		if s.Update(v) != wantBelow[i] {
+			t.Errorf("stream index %d mismatch", i)
+		}
+	}
+}
+
+func TestEdges(t *testing.T) {
+	entries, exits := signals.Edges(signals.HysteresisAbove([]float64{18, 26, 22, 19, 24, 25}, 25, 20))
+	if !sameBools(entries, []bool{false, true, false, false, false, true}) {
+		t.Errorf("entries: got %v", entries)
+	}
+	if !sameBools(exits, []bool{false, false, false, true, false, false}) {
+		t.Errorf("exits: got %v", exits)
+	}
+	if got := signals.Events(entries); len(got) != 2 || got[0] != 1 || got[1] != 5 {
+		t.Errorf("entry bars: got %v", got)
+	}
+
+	entries, _ = signals.Edges([]bool{true, true, false})
+	if !sameBools(entries, []bool{true, false, false}) {
+		t.Errorf("state on at bar 0: got %v", entries)
+	}
+}
+
+func TestBandSignals(t *testing.T) {
+	high := []float64{10, 12, 11, 13}
+	low := []float64{8, 9, 7, 10}
+	close := []float64{9, 11.5, 8, 12.5}
+	upper := []float64{11, 11, 11, 12}
+	lower := []float64{8.5, 8.5, 8.5, 9}
+
+	upTouch, lowTouch, err := signals.BandTouch(high, low, upper, lower)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !sameBools(upTouch, []bool{false, true, true, true}) || !sameBools(lowTouch, []bool{true, false, true, false}) {
+		t.Errorf("touch: got %v %v", upTouch, lowTouch)
+	}
+
+	up, down, err := signals.BandBreakout(close, upper, lower)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !sameBools(up, []bool{false, true, false, true}) || !sameBools(down, []bool{false, false, true, false}) {
+		t.Errorf("breakout: got %v %v", up, down)
+	}
+
+	s := signals.NewBandStream()
+	for i := range close {
+		ev := s.Update(high[i], low[i], close[i], upper[i], lower[i])
+		if ev.UpperTouch != upTouch[i] || ev.LowerTouch != lowTouch[i] || ev.BreakoutUp != up[i] || ev.BreakoutDown != down[i] {
+			t.Errorf("stream index %d: got %+v", i, ev)
+		}
+	}
+}
+
+func TestConditions(t *testing.T) {
+	above := []bool{true, true, false, true, true, true}
+	want := []bool{false, true, false, false, true, true}
+	if got := signals.Consecutive(above, 2); !sameBools(got, want) {
+		t.Errorf("Consecutive: got %v, want %v", got, want)
+	}
+	s := signals.NewConsecutiveStream(2)
+	for i, c := range above {
+		if s.Update(c) != want[i] {
+			t.Errorf("stream index %d mismatch", i)
+		}
+	}
+
+	values := []float64{1, 2, 3, 2, 1, 0}
+	if got := signals.Rising(values, 2); !sameBools(got, []bool{false, false, true, false, false, false}) {
+		t.Errorf("Rising: got %v", got)
+	}
+	if got := signals.Falling(values, 3); !sameBools(got, []bool{false, false, false, false, false, true}) {
+		t.Errorf("Falling: got %v", got)
+	}
+
+	since := signals.BarsSince([]bool{false, true, false, false, true})
+	wantSince := []int{-1, 0, 1, 2, 0}
+	for i := range since {
+		if since[i] != wantSince[i] {
+			t.Errorf("BarsSince: got %v, want %v", since, wantSince)
+			break
+		}
+	}
+
+	a, _ := signals.Above([]float64{1, 3, math.NaN()}, []float64{2, 2, 2})
+	b, _ := signals.Below([]float64{1, 3, 1}, []float64{2, 2, 2})
+	and, _ := signals.And(a, signals.Not(b))
+	or, err := signals.Or(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !sameBools(and, []bool{false, true, false}) || !sameBools(or, []bool{true, true, true}) {
+		t.Errorf("combinators: and %v or %v", and, or)
+	}
+	if _, err := signals.And(a, []bool{true}); err == nil {
+		t.Error("expected error for mismatched lengths")
+	}
+}
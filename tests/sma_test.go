@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"math"
 	"testing"
 
 	"github.com/copyleftdev/indicator-libs/indicators"
@@ -28,3 +29,28 @@ func TestSMA(t *testing.T) {
 		}
 	}
 }
+
+func TestSMAStream(t *testing.T) {
+	data := []float64{3, 5, 4, 8, 9, 7, 6, 10}
+	sma := indicators.NewSMA(3)
+	batch, err := sma.Calculate(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stream, err := sma.NewStream()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, p := range data {
+		got := stream.Update(p)
+		if i < 2 {
+			if !math.IsNaN(got) {
+				t.Errorf("index %d: got %v during warm-up, want NaN", i, got)
+			}
+			continue
+		}
+		if !sameFloat(got, batch[i]) {
+			t.Errorf("index %d: stream %v != batch %v", i, got, batch[i])
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestSMI(t *testing.T) {
+	highs := []float64{10, 10.5, 10.8, 11, 11.4, 11.6, 11.9, 12.3, 12.6, 12.8}
+	lows := []float64{9.5, 9.9, 10.2, 10.5, 10.9, 11.1, 11.4, 11.8, 12.1, 12.3}
+	closes := []float64{9.8, 10.4, 10.7, 10.9, 11.3, 11.5, 11.8, 12.2, 12.5, 12.7}
+
+	smi := indicators.NewSMI(3, 2, 2, 3)
+	line, signal, err := smi.Calculate(highs, lows, closes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(line) != len(closes) || len(signal) != len(closes) {
+		t.Fatalf("SMI outputs must match input length")
+	}
+	if !math.IsNaN(line[0]) || !math.IsNaN(line[1]) {
+		t.Errorf("expected NaN warm-up values, got %v, %v", line[0], line[1])
+	}
+
+	// Closes sit near the top of every range, so SMI should be positive.
+	for i := 2; i < len(line); i++ {
+		if line[i] <= 0 || line[i] > 100 {
+			t.Errorf("index %d: expected SMI in (0, 100], got %.4f", i, line[i])
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestTTMSqueeze(t *testing.T) {
+	// Quiet sideways bars with wide intrabar ranges keep the Bollinger Bands
+	// inside the Keltner Channels; a sharp rally then fires the squeeze.
+	var highs, lows, closes []float64
+	for i := 0; i < 20; i++ {
+		c := 100.0
+		if i%2 == 1 {
+			c = 100.2
+		}
+		highs = append(highs, c+1)
+		lows = append(lows, c-1)
+		closes = append(closes, c)
+	}
+	for i := 1; i <= 6; i++ {
+		c := 100 + float64(i*i)
+		highs = append(highs, c+1)
+		lows = append(lows, c-1)
+		closes = append(closes, c)
+	}
+
+	sq := indicators.NewTTMSqueeze(5, 2.0, 1.5)
+	on, fired, momentum, err := sq.Calculate(highs, lows, closes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(on) != len(closes) || len(fired) != len(closes) || len(momentum) != len(closes) {
+		t.Fatalf("output slice lengths must match input")
+	}
+
+	if !on[19] {
+		t.Error("expected squeeze to be on during the quiet period")
+	}
+	firedAt := -1
+	for i, f := range fired {
+		if f {
+			firedAt = i
+			break
+		}
+	}
+	if firedAt < 20 {
+		t.Fatalf("expected squeeze to fire after the rally starts, fired at %d", firedAt)
+	}
+	if on[firedAt] || !on[firedAt-1] {
+		t.Errorf("fired bar %d must end an active squeeze", firedAt)
+	}
+
+	if !math.IsNaN(momentum[7]) || math.IsNaN(momentum[8]) {
+		t.Errorf("unexpected momentum warm-up: m[7]=%v m[8]=%v", momentum[7], momentum[8])
+	}
+	if momentum[len(momentum)-1] <= 0 {
+		t.Errorf("expected positive momentum after a rally, got %.4f", momentum[len(momentum)-1])
+	}
+}
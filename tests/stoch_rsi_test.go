@@ -0,0 +1,36 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestStochRSI(t *testing.T) {
+	prices := []float64{
+		44.3, 44.1, 44.2, 43.6, 44.3, 44.8, 45.1, 45.4, 45.8, 46.1,
+		45.9, 46.2, 45.6, 46.3, 46.3, 46.0, 46.4, 46.2, 45.6, 46.2,
+	}
+	s := indicators.NewStochRSI(5, 5, 3, 3)
+	kVals, dVals, err := s.Calculate(prices)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(kVals) != len(prices) || len(dVals) != len(prices) {
+		t.Fatalf("StochRSI outputs must match input length")
+	}
+
+	// %K needs rsi(5) + stoch(5) - 1 + smooth(3) - 1 = index 11 before it is valid.
+	if !math.IsNaN(kVals[10]) || math.IsNaN(kVals[11]) {
+		t.Errorf("unexpected %%K warm-up: k[10]=%v, k[11]=%v", kVals[10], kVals[11])
+	}
+	if !math.IsNaN(dVals[12]) || math.IsNaN(dVals[13]) {
+		t.Errorf("unexpected %%D warm-up: d[12]=%v, d[13]=%v", dVals[12], dVals[13])
+	}
+	for i := 11; i < len(kVals); i++ {
+		if kVals[i] < 0 || kVals[i] > 100 {
+			t.Errorf("index %d: %%K %.2f out of [0, 100]", i, kVals[i])
+		}
+	}
+}
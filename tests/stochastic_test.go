@@ -29,3 +29,104 @@ func TestStochastic(t *testing.T) {
 		t.Error("expected valid final %K and %D values, got NaN")
 	}
 }
+
+func TestFullStochastic(t *testing.T) {
+	highs := []float64{5, 6, 7, 8, 9, 11, 12, 11, 10, 10, 11, 13}
+	lows := []float64{1, 2, 3, 3, 4, 5, 7, 8, 7, 6, 8, 9}
+	closes := []float64{3, 5, 6, 7, 8, 10, 12, 9, 8, 9, 10, 12}
+
+	fastK, _, err := indicators.NewStochasticOscillator(3, 3).Calculate(highs, lows, closes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	full := indicators.NewFullStochastic(3, 2, 3)
+	kVals, dVals, err := full.Calculate(highs, lows, closes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Slow %K is the 2-bar SMA of fast %K, valid from index 3; %D from index 5.
+	if !math.IsNaN(kVals[2]) || !math.IsNaN(dVals[4]) {
+		t.Errorf("unexpected warm-up: k[2]=%v d[4]=%v", kVals[2], dVals[4])
+	}
+	for i := 3; i < len(closes); i++ {
+		want := (fastK[i-1] + fastK[i]) / 2
+		if math.Abs(kVals[i]-want) > 1e-9 {
+			t.Errorf("index %d: slow %%K got %.4f, want %.4f", i, kVals[i], want)
+		}
+	}
+	for i := 5; i < len(closes); i++ {
+		want := (kVals[i-2] + kVals[i-1] + kVals[i]) / 3
+		if math.Abs(dVals[i]-want) > 1e-9 {
+			t.Errorf("index %d: %%D got %.4f, want %.4f", i, dVals[i], want)
+		}
+	}
+}
+
+func TestStochasticFlatRange(t *testing.T) {
+	highs := []float64{10, 12, 10, 10, 10}
+	lows := []float64{8, 9, 10, 10, 10}
+	closes := []float64{9, 11, 10, 10, 10}
+
+	cases := map[indicators.FlatRangeBehavior]float64{
+		indicators.FlatRange100: 100,
+		indicators.FlatRange50:  50,
+		indicators.FlatRange0:   0,
+	}
+	for behavior, want := range cases {
+		s := indicators.NewStochasticOscillator(2, 1)
+		s.FlatRange = behavior
+		kVals, _, err := s.Calculate(highs, lows, closes)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if kVals[4] != want {
+			t.Errorf("behavior %d: flat %%K got %.2f, want %.2f", behavior, kVals[4], want)
+		}
+	}
+
+	s := indicators.NewStochasticOscillator(2, 1)
+	s.FlatRange = indicators.FlatRangePrevious
+	kVals, _, err := s.Calculate(highs, lows, closes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// index 2 is not flat (range 9..12), so indices 3 and 4 repeat it.
+	if kVals[3] != kVals[2] || kVals[4] != kVals[2] {
+		t.Errorf("expected flat bars to repeat %%K %.2f, got %.2f and %.2f", kVals[2], kVals[3], kVals[4])
+	}
+}
+
+func TestStochasticStream(t *testing.T) {
+	highs := []float64{5, 6, 7, 8, 9, 11, 12, 11, 10, 10, 11, 13}
+	lows := []float64{1, 2, 3, 3, 4, 5, 7, 8, 7, 6, 8, 9}
+	closes := []float64{3, 5, 6, 7, 8, 10, 12, 9, 8, 9, 10, 12}
+
+	for _, maType := range []indicators.MAType{indicators.MASimple, indicators.MAExponential} {
+		s := indicators.NewFullStochastic(4, 3, 3)
+		s.MAType = maType
+		kVals, dVals, err := s.Calculate(highs, lows, closes)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		stream, err := s.NewStream()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for i := range closes {
+			k, d := stream.Update(highs[i], lows[i], closes[i])
+			if !sameFloat(k, kVals[i]) || !sameFloat(d, dVals[i]) {
+				t.Errorf("MA type %d index %d: stream (%.4f, %.4f) != batch (%.4f, %.4f)",
+					maType, i, k, d, kVals[i], dVals[i])
+			}
+		}
+	}
+}
+
+// sameFloat treats two NaNs as equal.
+func sameFloat(a, b float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.IsNaN(a) && math.IsNaN(b)
+	}
+	return math.Abs(a-b) < 1e-9
+}
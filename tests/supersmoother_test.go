@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestSuperSmoother(t *testing.T) {
+	// The filter has unity gain, so a constant series passes through unchanged.
+	flat := []float64{7, 7, 7, 7, 7, 7, 7, 7}
+	got, err := indicators.NewSuperSmoother(4).Calculate(flat)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, v := range got {
+		if math.Abs(v-7) > 1e-9 {
+			t.Errorf("index %d: got %.6f, want 7", i, v)
+		}
+	}
+}
+
+func TestRoofingFilter(t *testing.T) {
+	// A straight-line trend has no cycle content, so the roofing filter settles at 0.
+	prices := make([]float64, 150)
+	for i := range prices {
+		prices[i] = 50 + 0.25*float64(i)
+	}
+	got, err := indicators.NewRoofingFilter(48, 10).Calculate(prices)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 2; i < len(got); i++ {
+		if math.Abs(got[i]) > 1e-9 {
+			t.Errorf("index %d: expected 0 for a linear trend, got %.6f", i, got[i])
+		}
+	}
+
+	if _, err := indicators.NewRoofingFilter(10, 48).Calculate(prices); err == nil {
+		t.Error("expected error when highPassPeriod <= lowPassPeriod")
+	}
+}
@@ -49,3 +49,98 @@ func TestSuperTrend(t *testing.T) {
 		finalUB[len(close)-1],
 		finalLB[len(close)-1])
 }
+
+func TestSuperTrendSignals(t *testing.T) {
+	// Rally, sell-off, rally: the trend should flip both ways.
+	open := []float64{10, 10.4, 10.9, 11.5, 12.1, 11.8, 11.0, 10.2, 9.5, 9.9, 10.6, 11.4, 12.2, 13.0}
+	high := []float64{10.5, 11, 11.6, 12.2, 12.4, 12.0, 11.2, 10.4, 10.0, 10.7, 11.5, 12.3, 13.1, 13.8}
+	low := []float64{9.8, 10.2, 10.8, 11.4, 11.7, 10.9, 10.1, 9.4, 9.2, 9.8, 10.5, 11.3, 12.1, 12.9}
+	close := []float64{10.4, 10.9, 11.5, 12.1, 11.8, 11.0, 10.2, 9.5, 9.9, 10.6, 11.4, 12.2, 13.0, 13.6}
+
+	st := indicators.NewSuperTrend(3, 1.0)
+	res, err := st.CalculateSignals(nil, high, low, close)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var buys, sells int
+	for i := 1; i < len(close); i++ {
+		flippedUp := res.Direction[i-1] == -1 && res.Direction[i] == 1
+		flippedDown := res.Direction[i-1] == 1 && res.Direction[i] == -1
+		if res.Buy[i] != flippedUp || res.Sell[i] != flippedDown {
+			t.Errorf("index %d: buy/sell flags inconsistent with direction change", i)
+		}
+		if res.Buy[i] {
+			buys++
+		}
+		if res.Sell[i] {
+			sells++
+		}
+	}
+	if buys == 0 || sells == 0 {
+		t.Errorf("expected both buy and sell flips, got %d buys and %d sells", buys, sells)
+	}
+
+	// Calculate must stay consistent with the default hl2 / Wilder configuration.
+	line, _, _, _, err := st.Calculate(high, low, close)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range line {
+		if line[i] != res.Line[i] {
+			t.Errorf("index %d: Calculate and CalculateSignals disagree", i)
+		}
+	}
+
+	// Other sources and SMA-based ATR produce different bands.
+	variant := indicators.NewSuperTrend(3, 1.0)
+	variant.Source = indicators.SourceOHLC4
+	variant.ATRSmoothing = indicators.MASimple
+	alt, err := variant.CalculateSignals(open, high, low, close)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	last := len(close) - 1
+	if alt.FinalUB[last] == res.FinalUB[last] && alt.FinalLB[last] == res.FinalLB[last] {
+		t.Error("expected ohlc4/SMA variant to differ from hl2/Wilder")
+	}
+	if _, err := variant.CalculateSignals(nil, high, low, close); err == nil {
+		t.Error("expected error for ohlc4 source without open prices")
+	}
+}
+
+func TestSuperTrendStream(t *testing.T) {
+	bars := waveBars(120)
+	for _, src := range []indicators.PriceSource{indicators.SourceHL2, indicators.SourceOHLC4} {
+		st := indicators.NewSuperTrend(10, 2)
+		st.Source = src
+		res, err := st.CalculateSignals(bars.Open, bars.High, bars.Low, bars.Close)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		stream, err := st.NewStream()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		flips := 0
+		for i := range bars.Close {
+			line, dir, buy, sell := stream.Update(bars.Open[i], bars.High[i], bars.Low[i], bars.Close[i])
+			if i < 9 {
+				if !math.IsNaN(line) || dir != 0 {
+					t.Errorf("source %d index %d: got (%v, %d) during warm-up", src, i, line, dir)
+				}
+				continue
+			}
+			if !sameFloat(line, res.Line[i]) || dir != res.Direction[i] || buy != res.Buy[i] || sell != res.Sell[i] {
+				t.Errorf("source %d index %d: stream (%v, %d, %v, %v) != batch (%v, %d, %v, %v)",
+					src, i, line, dir, buy, sell, res.Line[i], res.Direction[i], res.Buy[i], res.Sell[i])
+			}
+			if buy || sell {
+				flips++
+			}
+		}
+		if flips == 0 {
+			t.Errorf("source %d: expected the test data to flip the trend", src)
+		}
+	}
+}
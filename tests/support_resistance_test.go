@@ -0,0 +1,35 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestSupportResistance(t *testing.T) {
+	// swing highs near 20 at bars 2 and 8, swing lows near 10 at bars 5 and 11
+	highs := []float64{15, 17, 20, 17, 14, 12, 15, 18, 20.05, 17, 14, 12.2, 14, 16}
+	lows := []float64{13, 15, 18, 15, 12, 10, 13, 16, 18, 15, 12, 10.04, 12, 14}
+	closes := []float64{14, 16, 19, 16, 13, 11, 14, 17, 19, 16, 13, 11, 13, 15}
+
+	sr := indicators.NewSupportResistance(2, 0.01, 2)
+	levels, err := sr.Calculate(highs, lows, closes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(levels) != 2 {
+		t.Fatalf("got %d levels (%+v), want 2", len(levels), levels)
+	}
+	sup, res := levels[0], levels[1]
+	if math.Abs(sup.Price-10.02) > 1e-9 || !sup.Support || sup.Touches != 2 || sup.FirstIndex != 5 || sup.LastIndex != 11 {
+		t.Errorf("support: %+v", sup)
+	}
+	if math.Abs(res.Price-20.025) > 1e-9 || res.Support || res.Touches != 2 || res.FirstIndex != 2 || res.LastIndex != 8 {
+		t.Errorf("resistance: %+v", res)
+	}
+
+	if _, err := sr.Calculate(highs[:4], lows[:4], closes[:4]); err == nil {
+		t.Error("expected error for insufficient data")
+	}
+}
@@ -38,3 +38,48 @@ func TestT3(t *testing.T) {
 	// Optionally compare final T3 vs. an expected reference from a known source
 	t.Logf("Final T3 value = %.4f", t3vals[len(t3vals)-1])
 }
+
+func TestT3TillsonReference(t *testing.T) {
+	prices := []float64{10, 11, 12, 11, 13}
+
+	// Reference values from Tillson's definition T3 = GD(GD(GD(x))),
+	// GD(x) = EMA(x)*(1+v) - EMA(EMA(x))*v, with period=2 and v=0.7.
+	want := []float64{10.0, 10.555862825788754, 11.457262002743487, 11.363603109282122, 12.261736523903878}
+
+	got, err := indicators.NewT3(2, 0.7).Calculate(prices)
+	if err != nil {
+		t.Fatalf("T3 calculation error: %v", err)
+	}
+	for i, w := range want {
+		if math.Abs(got[i]-w) > 1e-9 {
+			t.Errorf("index %d: got %.10f, want %.10f", i, got[i], w)
+		}
+	}
+
+	// A constant series must stay constant in both modes.
+	flat := []float64{5, 5, 5, 5, 5, 5}
+	for _, mode := range []indicators.T3Mode{indicators.T3Tillson, indicators.T3Legacy} {
+		t3Calc := indicators.NewT3(3, 0.7)
+		t3Calc.Mode = mode
+		vals, err := t3Calc.Calculate(flat)
+		if err != nil {
+			t.Fatalf("mode %d: unexpected error: %v", mode, err)
+		}
+		for i, v := range vals {
+			if math.Abs(v-5) > 1e-9 {
+				t.Errorf("mode %d index %d: got %.6f, want 5", mode, i, v)
+			}
+		}
+	}
+
+	// The legacy combination is still available and differs from Tillson's.
+	legacy := indicators.NewT3(2, 0.7)
+	legacy.Mode = indicators.T3Legacy
+	legacyVals, err := legacy.Calculate(prices)
+	if err != nil {
+		t.Fatalf("legacy T3 error: %v", err)
+	}
+	if math.Abs(legacyVals[4]-got[4]) < 1e-6 {
+		t.Error("expected legacy T3 to differ from Tillson's formula")
+	}
+}
@@ -0,0 +1,29 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestTSI(t *testing.T) {
+	// Steadily rising prices should give a TSI of +100 (every change is positive).
+	data := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	tsi := indicators.NewTSI(4, 2, 3)
+	line, signal, err := tsi.Calculate(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(line) != len(data) || len(signal) != len(data) {
+		t.Fatalf("TSI outputs must match input length")
+	}
+	for i := 1; i < len(line); i++ {
+		if math.Abs(line[i]-100) > 1e-9 {
+			t.Errorf("index %d: got %.5f, want 100", i, line[i])
+		}
+	}
+	if math.IsNaN(signal[len(signal)-1]) {
+		t.Error("expected final TSI signal not to be NaN")
+	}
+}
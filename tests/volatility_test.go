@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestHistoricalVolatility(t *testing.T) {
+	// Every bar opens and closes at 100 with a high/low ratio of e^0.02.
+	n := 10
+	opens := make([]float64, n)
+	highs := make([]float64, n)
+	lows := make([]float64, n)
+	closes := make([]float64, n)
+	for i := 0; i < n; i++ {
+		opens[i] = 100
+		closes[i] = 100
+		lows[i] = 99
+		highs[i] = 99 * math.Exp(0.02)
+	}
+
+	x2 := 0.02 * 0.02
+	cases := []struct {
+		estimator indicators.VolatilityEstimator
+		first     int
+		want      float64
+	}{
+		{indicators.VolatilityParkinson, 4, math.Sqrt(252 * x2 / (4 * math.Ln2))},
+		{indicators.VolatilityGarmanKlass, 4, math.Sqrt(252 * 0.5 * x2)},
+		// Flat closes and opens: no close-to-close or overnight variance.
+		{indicators.VolatilityCloseToClose, 5, 0},
+	}
+	for _, c := range cases {
+		hv := indicators.NewHistoricalVolatility(5, c.estimator, 252)
+		got, err := hv.Calculate(opens, highs, lows, closes)
+		if err != nil {
+			t.Fatalf("estimator %d: unexpected error: %v", c.estimator, err)
+		}
+		if !math.IsNaN(got[c.first-1]) {
+			t.Errorf("estimator %d: expected NaN at index %d, got %v", c.estimator, c.first-1, got[c.first-1])
+		}
+		for i := c.first; i < n; i++ {
+			if math.Abs(got[i]-c.want) > 1e-9 {
+				t.Errorf("estimator %d index %d: got %.6f, want %.6f", c.estimator, i, got[i], c.want)
+			}
+		}
+	}
+
+	// Rogers-Satchell and Yang-Zhang on a trending series should be finite and positive.
+	trendOpen := []float64{100, 101, 102.5, 101.8, 103, 104.2, 103.9, 105, 106.1, 105.7}
+	trendHigh := []float64{101.5, 103, 103.2, 103.5, 104.8, 105, 105.3, 106.5, 107, 106.9}
+	trendLow := []float64{99.5, 100.6, 101.2, 101, 102.6, 103.1, 103.2, 104.4, 105.2, 104.8}
+	trendClose := []float64{101, 102.4, 101.9, 103.1, 104.1, 104, 105, 106, 105.8, 106.5}
+	for _, est := range []indicators.VolatilityEstimator{indicators.VolatilityRogersSatchell, indicators.VolatilityYangZhang} {
+		bars := &indicators.OHLCV{Open: trendOpen, High: trendHigh, Low: trendLow, Close: trendClose}
+		got, err := indicators.NewHistoricalVolatility(5, est, 252).CalculateOHLCV(bars)
+		if err != nil {
+			t.Fatalf("estimator %d: unexpected error: %v", est, err)
+		}
+		last := got[len(got)-1]
+		if math.IsNaN(last) || last <= 0 {
+			t.Errorf("estimator %d: expected positive volatility, got %v", est, last)
+		}
+	}
+
+	// Close-to-close and Parkinson work on HLC data without opens; the others need them.
+	for _, est := range []indicators.VolatilityEstimator{indicators.VolatilityCloseToClose, indicators.VolatilityParkinson} {
+		withOpen, _ := indicators.NewHistoricalVolatility(5, est, 252).Calculate(opens, highs, lows, closes)
+		got, err := indicators.NewHistoricalVolatility(5, est, 252).Calculate(nil, highs, lows, closes)
+		if err != nil {
+			t.Fatalf("estimator %d without opens: unexpected error: %v", est, err)
+		}
+		for i := range got {
+			if !sameFloat(got[i], withOpen[i]) {
+				t.Errorf("estimator %d index %d: got %v without opens, %v with", est, i, got[i], withOpen[i])
+			}
+		}
+	}
+	hlc := &indicators.OHLCV{High: highs, Low: lows, Close: closes}
+	if _, err := indicators.NewHistoricalVolatility(5, indicators.VolatilityParkinson, 252).CalculateOHLCV(hlc); err != nil {
+		t.Errorf("Parkinson on HLC bars: unexpected error: %v", err)
+	}
+	if _, err := indicators.NewHistoricalVolatility(5, indicators.VolatilityGarmanKlass, 252).CalculateOHLCV(hlc); err == nil {
+		t.Error("expected error for Garman-Klass without opens")
+	}
+
+	if _, err := indicators.NewHistoricalVolatility(5, indicators.VolatilityParkinson, 0).Calculate(opens, highs, lows, closes); err == nil {
+		t.Error("expected error for a zero annualization factor")
+	}
+}
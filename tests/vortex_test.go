@@ -0,0 +1,33 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestVortex(t *testing.T) {
+	highs := []float64{10, 10.5, 11, 11.5, 12, 12.5, 13}
+	lows := []float64{9, 9.5, 10, 10.5, 11, 11.5, 12}
+	closes := []float64{9.8, 10.3, 10.8, 11.3, 11.8, 12.3, 12.8}
+
+	vortex := indicators.NewVortex(3)
+	plusVI, minusVI, err := vortex.Calculate(highs, lows, closes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plusVI) != len(highs) || len(minusVI) != len(highs) {
+		t.Fatalf("Vortex outputs must match input length")
+	}
+	if !math.IsNaN(plusVI[2]) {
+		t.Errorf("expected NaN before a full window, got %v", plusVI[2])
+	}
+
+	// Every bar: VM+ = |H - prevL| = 1.5, VM- = |L - prevH| = 0.5, TR = 1.0
+	for i := 3; i < len(highs); i++ {
+		if math.Abs(plusVI[i]-1.5) > 1e-9 || math.Abs(minusVI[i]-0.5) > 1e-9 {
+			t.Errorf("index %d: got +VI=%.4f -VI=%.4f, want 1.5 and 0.5", i, plusVI[i], minusVI[i])
+		}
+	}
+}
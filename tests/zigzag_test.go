@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/copyleftdev/indicator-libs/indicators"
+)
+
+func TestZigZagPercent(t *testing.T) {
+	prices := []float64{100, 105, 110, 104, 98, 97, 103, 108, 112, 109}
+	res, err := indicators.NewZigZag(10).Calculate(prices, prices, prices)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []indicators.ZigZagPivot{
+		{Index: 0, Price: 100, Kind: -1, ConfirmedIndex: 2},
+		{Index: 2, Price: 110, Kind: 1, ConfirmedIndex: 4},
+		{Index: 5, Price: 97, Kind: -1, ConfirmedIndex: 7},
+		{Index: 8, Price: 112, Kind: 1, ConfirmedIndex: -1}, // tentative
+	}
+	if len(res.Pivots) != len(want) {
+		t.Fatalf("got %d pivots (%+v), want %d", len(res.Pivots), res.Pivots, len(want))
+	}
+	for i, w := range want {
+		if res.Pivots[i] != w {
+			t.Errorf("pivot %d: got %+v, want %+v", i, res.Pivots[i], w)
+		}
+	}
+
+	wantConfirmed := []int{0, 0, -1, 0, 1, 0, 0, -1, 0, 0}
+	for i, w := range wantConfirmed {
+		if res.Confirmed[i] != w {
+			t.Errorf("confirmed[%d]: got %d, want %d", i, res.Confirmed[i], w)
+		}
+	}
+	if res.Value[8] != 112 || !math.IsNaN(res.Value[1]) {
+		t.Errorf("value series: got %v", res.Value)
+	}
+}
+
+func TestZigZagATR(t *testing.T) {
+	closes := []float64{10, 10, 10, 14, 14, 9}
+	highs := make([]float64, len(closes))
+	lows := make([]float64, len(closes))
+	for i, c := range closes {
+		highs[i], lows[i] = c+1, c-1
+	}
+
+	// ATR(2): 2, 2, 3.5, 2.75, 4.375 from index 1; threshold is 2 * ATR
+	res, err := indicators.NewATRZigZag(2, 2).Calculate(highs, lows, closes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the low at 0 is confirmed at bar 4 (15 - 9 >= 5.5); the swing high is bar 3,
+	// not the confirming bar, and the drop at bar 5 (7 < 8.75) doesn't confirm it
+	want := []indicators.ZigZagPivot{
+		{Index: 0, Price: 9, Kind: -1, ConfirmedIndex: 4},
+		{Index: 3, Price: 15, Kind: 1, ConfirmedIndex: -1},
+	}
+	if len(res.Pivots) != len(want) {
+		t.Fatalf("got %d pivots (%+v), want %d", len(res.Pivots), res.Pivots, len(want))
+	}
+	for i, w := range want {
+		if res.Pivots[i] != w {
+			t.Errorf("pivot %d: got %+v, want %+v", i, res.Pivots[i], w)
+		}
+	}
+
+	if _, err := indicators.NewZigZag(0).Calculate(highs, lows, closes); err == nil {
+		t.Error("expected error for zero percent")
+	}
+}